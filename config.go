@@ -5,9 +5,13 @@ package qry
 // logging dependencies with it.
 
 import (
+	"context"
 	"log"
+	"math/big"
 	"net/url"
 	"reflect"
+	"regexp"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.uber.org/zap"
@@ -35,21 +39,40 @@ var configDefaultLevelModes = levelModes{
 
 // Config TODO
 type Config struct {
+	Atomic            bool
 	Convert           ConfigConvert
+	Handlers          []Handler
+	Hooks             ConfigHook
 	IgnoreInvalidKeys bool
+	Limits            ConfigLimits
 	LogTrace          Trace
 	Separators        ConfigSeparate
 	SetModes          SetOptionsMap
 	StructParse       ConfigStructParse
+	Unmarshal         ConfigUnmarshal
 }
 
 func defaultConfig() Config {
 	return Config{
+		Atomic: false,
 		Convert: ConfigConvert{
-			IntegerBase: 0,
-			Unescape:    url.QueryUnescape,
+			BigFloatMode:      big.ToNearestEven,
+			BigFloatPrecision: 0,
+			BoolFalse:         []string{"0", "f", "false"},
+			BoolFlag:          false,
+			BoolTrue:          []string{"1", "t", "true"},
+			ByteEncoding:      ByteEncodingRaw,
+			ComplexPairs:      false,
+			Empty:             EmptyDecode,
+			IntegerBase:       0,
+			TimeLayouts:       []string{time.RFC3339},
+			TimeLocation:      time.UTC,
+			Unescape:          url.QueryUnescape,
 		},
+		Handlers:          defaultHandlers(),
+		Hooks:             ConfigHook{},
 		IgnoreInvalidKeys: false,
+		Limits:            ConfigLimits{},
 		LogTrace:          nil,
 		Separators: ConfigSeparate{
 			Fields:   newSeparatorSet('&').Split, // TODO: Add ';' to default Fields separator set? Check RFC
@@ -62,6 +85,11 @@ func defaultConfig() Config {
 			BaseTagName: configDefaultBaseTagName,
 			SetTagName:  configDefaultSetTagName,
 		},
+		Unmarshal: ConfigUnmarshal{
+			Binary: false,
+			Flag:   false,
+			JSON:   false,
+		},
 	}
 }
 
@@ -87,15 +115,43 @@ func (c Config) NewDecoder(opts ...Option) (*Decoder, error) {
 		return nil, err
 	}
 
+	if err := validateHandlers(cfg.Handlers); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Hooks.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Limits.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Convert.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Separators.validate(); err != nil {
+		return nil, err
+	}
+
 	var (
-		converter    = newConverter(cfg.Convert)
-		unmarshaler  = newUnmarshaler(converter.Unescape)
-		structParser = newStructParser(cfg.StructParse, unmarshaler.check)
+		converter   = newConverter(cfg.Convert)
+		unmarshaler = newUnmarshaler(cfg.Unmarshal)
+
+		// Types with a registered converter are treated as unmarshalers for
+		// the purposes of struct parsing
+		checkUnmarshaler = func(t reflect.Type) bool { return converter.checkType(t) || unmarshaler.check(t) }
+		structParser     = newStructParser(cfg.StructParse, checkUnmarshaler, converter.checkTransform)
 	)
 
 	return &Decoder{
+		atomic:            cfg.Atomic,
 		baseModes:         configDefaultLevelModes.with(cfg.SetModes),
+		handlers:          cfg.Handlers,
+		hooks:             cfg.Hooks,
 		ignoreInvalidKeys: cfg.IgnoreInvalidKeys,
+		limits:            cfg.Limits,
 		logTrace:          cfg.LogTrace,
 		separators:        cfg.Separators,
 
@@ -110,18 +166,165 @@ func (c Config) NewDecoder(opts ...Option) (*Decoder, error) {
 // Option TODO
 type Option func(*Config)
 
+// ----- Atomic option
+
+// Atomic TODO
+func Atomic(b bool) Option {
+	return func(c *Config) { c.Atomic = b }
+}
+
 // ----- Convert options
 
+// ConvertBigFloatModeAs TODO
+func ConvertBigFloatModeAs(mode big.RoundingMode) Option {
+	return func(c *Config) { c.Convert.BigFloatMode = mode }
+}
+
+// ConvertBigFloatPrecisionAs TODO
+func ConvertBigFloatPrecisionAs(prec uint) Option {
+	return func(c *Config) { c.Convert.BigFloatPrecision = prec }
+}
+
+// ConvertBoolFlag TODO
+func ConvertBoolFlag(b bool) Option {
+	return func(c *Config) { c.Convert.BoolFlag = b }
+}
+
+// ConvertBoolWordsAs TODO
+// Words are matched case-insensitively
+func ConvertBoolWordsAs(truthy, falsy []string) Option {
+	return func(c *Config) { c.Convert.BoolTrue, c.Convert.BoolFalse = truthy, falsy }
+}
+
+// ConvertByteEncodingAs TODO
+func ConvertByteEncodingAs(encoding string) Option {
+	return func(c *Config) { c.Convert.ByteEncoding = encoding }
+}
+
+// ConvertComplexPairs TODO
+func ConvertComplexPairs(b bool) Option {
+	return func(c *Config) { c.Convert.ComplexPairs = b }
+}
+
+// ConvertEmptyAs TODO
+func ConvertEmptyAs(mode EmptyMode) Option {
+	return func(c *Config) { c.Convert.Empty = mode }
+}
+
 // ConvertIntegerBaseAs TODO
 func ConvertIntegerBaseAs(base int) Option {
 	return func(c *Config) { c.Convert.IntegerBase = base }
 }
 
+// ConvertTimeLayoutsAs TODO
+func ConvertTimeLayoutsAs(layouts ...string) Option {
+	return func(c *Config) { c.Convert.TimeLayouts = layouts }
+}
+
+// ConvertTimeLocationAs TODO
+func ConvertTimeLocationAs(loc *time.Location) Option {
+	return func(c *Config) { c.Convert.TimeLocation = loc }
+}
+
+// ConvertTransformVia TODO
+// Registered transforms override built-ins of the same name
+func ConvertTransformVia(name string, transform func(string) string) Option {
+	return func(c *Config) {
+		// Copy rather than modify in place, as the map may be shared with
+		// the config this one was derived from
+		transforms := make(map[string]func(string) string, len(c.Convert.Transforms)+1)
+		for k, v := range c.Convert.Transforms {
+			transforms[k] = v
+		}
+
+		transforms[name] = transform
+		c.Convert.Transforms = transforms
+	}
+}
+
+// ConvertTypeVia TODO
+func ConvertTypeVia(t reflect.Type, set func(string, reflect.Value) error, levels ...DecodeLevel) Option {
+	return func(c *Config) {
+		// Copy rather than modify in place, as the map may be shared with
+		// the config this one was derived from
+		types := make(map[reflect.Type]ConvertType, len(c.Convert.Types)+1)
+		for k, v := range c.Convert.Types {
+			types[k] = v
+		}
+
+		types[t] = ConvertType{Levels: levels, Set: set}
+		c.Convert.Types = types
+	}
+}
+
+// ConvertTypeAs TODO
+func ConvertTypeAs[T any](parse func(string) (T, error), levels ...DecodeLevel) Option {
+	set := func(str string, val reflect.Value) error {
+		res, err := parse(str)
+		if err != nil {
+			return err
+		}
+
+		val.Set(reflect.ValueOf(&res).Elem())
+		return nil
+	}
+
+	return ConvertTypeVia(reflect.TypeOf((*T)(nil)).Elem(), set, levels...)
+}
+
 // ConvertUnescapeAs TODO
 func ConvertUnescapeAs(unescape func(string) (string, error)) Option {
 	return func(c *Config) { c.Convert.Unescape = unescape }
 }
 
+// ----- Handler options
+
+// HandleBefore TODO
+// Handlers are inserted ahead of the current chain, built-ins included
+func HandleBefore(handlers ...Handler) Option {
+	return func(c *Config) {
+		// Copy rather than modify in place, as the slice may be shared with
+		// the config this one was derived from
+		res := make([]Handler, 0, len(handlers)+len(c.Handlers))
+		c.Handlers = append(append(res, handlers...), c.Handlers...)
+	}
+}
+
+// HandleAfter TODO
+// Handlers are appended to the current chain, built-ins included
+func HandleAfter(handlers ...Handler) Option {
+	return func(c *Config) {
+		res := make([]Handler, 0, len(c.Handlers)+len(handlers))
+		c.Handlers = append(append(res, c.Handlers...), handlers...)
+	}
+}
+
+// HandleVia TODO
+// Replaces the entire chain, see HandlerTypes() et al. for the built-ins
+func HandleVia(handlers ...Handler) Option {
+	return func(c *Config) { c.Handlers = handlers }
+}
+
+// ----- Hook options
+
+// HookDecodeVia TODO
+// Hooks are appended to any already configured
+func HookDecodeVia(hooks ...DecodeHook) Option {
+	return func(c *Config) {
+		res := make([]DecodeHook, 0, len(c.Hooks.Decode)+len(hooks))
+		c.Hooks.Decode = append(append(res, c.Hooks.Decode...), hooks...)
+	}
+}
+
+// HookPostDecodeVia TODO
+// Hooks are appended to any already configured
+func HookPostDecodeVia(hooks ...PostDecodeHook) Option {
+	return func(c *Config) {
+		res := make([]PostDecodeHook, 0, len(c.Hooks.PostDecode)+len(hooks))
+		c.Hooks.PostDecode = append(append(res, c.Hooks.PostDecode...), hooks...)
+	}
+}
+
 // ----- Ignore invalid keys option
 
 // IgnoreInvalidKeys TODO
@@ -129,26 +332,232 @@ func IgnoreInvalidKeys(b bool) Option {
 	return func(c *Config) { c.IgnoreInvalidKeys = b }
 }
 
+// ----- Limit options
+
+// LimitInputLengthTo TODO
+func LimitInputLengthTo(n int) Option {
+	return func(c *Config) { c.Limits.InputLength = n }
+}
+
+// LimitFieldsTo TODO
+func LimitFieldsTo(n int) Option {
+	return func(c *Config) { c.Limits.Fields = n }
+}
+
+// LimitKeyChainDepthTo TODO
+func LimitKeyChainDepthTo(n int) Option {
+	return func(c *Config) { c.Limits.KeyChainDepth = n }
+}
+
+// LimitValuesTo TODO
+func LimitValuesTo(n int) Option {
+	return func(c *Config) { c.Limits.Values = n }
+}
+
+// LimitMapEntriesTo TODO
+func LimitMapEntriesTo(n int) Option {
+	return func(c *Config) { c.Limits.MapEntries = n }
+}
+
 // ----- Separator options
 
 // SeparateFieldsBy TODO
 func SeparateFieldsBy(seps ...rune) Option {
-	return func(c *Config) { c.Separators.Fields = newSeparatorSet(seps...).Split }
+	return func(c *Config) {
+		c.Separators.Fields = newSeparatorSet(seps...).Split
+		c.Separators.errs.fields = nil
+	}
+}
+
+// SeparateFieldsByString TODO
+func SeparateFieldsByString(seps ...string) Option {
+	return func(c *Config) {
+		c.Separators.Fields = newStringSeparators(seps...).Split
+		c.Separators.errs.fields = nil
+	}
+}
+
+// SeparateFieldsByRegexp TODO
+// As with all regexp separator options, NewDecoder fails given a nil re or one
+// that may match the empty string, unless superseded by a later option for the
+// same separator
+func SeparateFieldsByRegexp(re *regexp.Regexp) Option {
+	return func(c *Config) {
+		c.Separators.Fields = regexpSeparator{re}.Split
+		c.Separators.errs.fields = validateSeparatorRegexp(re)
+	}
 }
 
 // SeparateKeyValsBy TODO
 func SeparateKeyValsBy(seps ...rune) Option {
-	return func(c *Config) { c.Separators.KeyVals = newSeparatorSet(seps...).Pair }
+	return func(c *Config) {
+		c.Separators.KeyVals = newSeparatorSet(seps...).Pair
+		c.Separators.errs.keyVals = nil
+	}
+}
+
+// SeparateKeyValsByString TODO
+func SeparateKeyValsByString(seps ...string) Option {
+	return func(c *Config) {
+		c.Separators.KeyVals = newStringSeparators(seps...).Pair
+		c.Separators.errs.keyVals = nil
+	}
+}
+
+// SeparateKeyValsByRegexp TODO
+func SeparateKeyValsByRegexp(re *regexp.Regexp) Option {
+	return func(c *Config) {
+		c.Separators.KeyVals = regexpSeparator{re}.Pair
+		c.Separators.errs.keyVals = validateSeparatorRegexp(re)
+	}
 }
 
 // SeparateKeyChainBy TODO
 func SeparateKeyChainBy(seps ...rune) Option {
-	return func(c *Config) { c.Separators.KeyChain = newSeparatorSet(seps...).Split }
+	return func(c *Config) {
+		c.Separators.KeyChain = newSeparatorSet(seps...).Split
+		c.Separators.errs.keyChain = nil
+	}
+}
+
+// SeparateKeyChainByString TODO
+func SeparateKeyChainByString(seps ...string) Option {
+	return func(c *Config) {
+		c.Separators.KeyChain = newStringSeparators(seps...).Split
+		c.Separators.errs.keyChain = nil
+	}
+}
+
+// SeparateKeyChainByRegexp TODO
+func SeparateKeyChainByRegexp(re *regexp.Regexp) Option {
+	return func(c *Config) {
+		c.Separators.KeyChain = regexpSeparator{re}.Split
+		c.Separators.errs.keyChain = validateSeparatorRegexp(re)
+	}
+}
+
+// SeparateKeyChainEscapedBy TODO
+func SeparateKeyChainEscapedBy(seps ...rune) Option {
+	return func(c *Config) {
+		c.Separators.KeyChain = newEscapeSplitter(seps, true, false).Split
+		c.Separators.errs.keyChain = nil
+	}
+}
+
+// SeparateKeyChainQuotedBy TODO
+func SeparateKeyChainQuotedBy(seps ...rune) Option {
+	return func(c *Config) {
+		c.Separators.KeyChain = newEscapeSplitter(seps, false, true).Split
+		c.Separators.errs.keyChain = nil
+	}
+}
+
+// SeparateKeyChainQuotedEscapedBy TODO
+func SeparateKeyChainQuotedEscapedBy(seps ...rune) Option {
+	return func(c *Config) {
+		c.Separators.KeyChain = newEscapeSplitter(seps, true, true).Split
+		c.Separators.errs.keyChain = nil
+	}
+}
+
+// SeparateKeyComponentsBy TODO
+func SeparateKeyComponentsBy(seps ...rune) Option {
+	return func(c *Config) {
+		c.Separators.KeyComponents = newSeparatorSet(seps...).Split
+		c.Separators.errs.keyComponents = nil
+	}
+}
+
+// SeparateKeyComponentsByString TODO
+func SeparateKeyComponentsByString(seps ...string) Option {
+	return func(c *Config) {
+		c.Separators.KeyComponents = newStringSeparators(seps...).Split
+		c.Separators.errs.keyComponents = nil
+	}
+}
+
+// SeparateKeyComponentsByRegexp TODO
+func SeparateKeyComponentsByRegexp(re *regexp.Regexp) Option {
+	return func(c *Config) {
+		c.Separators.KeyComponents = regexpSeparator{re}.Split
+		c.Separators.errs.keyComponents = validateSeparatorRegexp(re)
+	}
 }
 
 // SeparateValuesBy TODO
 func SeparateValuesBy(seps ...rune) Option {
-	return func(c *Config) { c.Separators.Values = newSeparatorSet(seps...).Split }
+	return func(c *Config) {
+		c.Separators.Values = newSeparatorSet(seps...).Split
+		c.Separators.errs.values = nil
+	}
+}
+
+// SeparateValuesByString TODO
+func SeparateValuesByString(seps ...string) Option {
+	return func(c *Config) {
+		c.Separators.Values = newStringSeparators(seps...).Split
+		c.Separators.errs.values = nil
+	}
+}
+
+// SeparateValuesByRegexp TODO
+func SeparateValuesByRegexp(re *regexp.Regexp) Option {
+	return func(c *Config) {
+		c.Separators.Values = regexpSeparator{re}.Split
+		c.Separators.errs.values = validateSeparatorRegexp(re)
+	}
+}
+
+// SeparateNestedValuesBy TODO
+// One separator per nesting level, outermost first (e.g. ';' for "1,2;3,4"
+// into [][]int)
+func SeparateNestedValuesBy(seps ...rune) Option {
+	return func(c *Config) {
+		c.Separators.NestedValues = make([]func(string) []string, len(seps))
+		for i, sep := range seps {
+			c.Separators.NestedValues[i] = newSeparatorSet(sep).Split
+		}
+	}
+}
+
+// SeparateValuesKeepEmptyBy TODO
+// Unlike SeparateValuesBy, empty values between separators are kept (e.g.
+// "1,,3" => ["1", "", "3"]) for handling per the empty mode
+func SeparateValuesKeepEmptyBy(seps ...rune) Option {
+	return func(c *Config) {
+		c.Separators.Values = newSeparatorSet(seps...).SplitKeepEmpty
+		c.Separators.errs.values = nil
+	}
+}
+
+// SeparateValuesEscapedBy TODO
+// Separators preceded by a literal backslash do not split (e.g. `a\,b,c` =>
+// ["a,b", "c"]), whereas "%5C" is taken as user text
+func SeparateValuesEscapedBy(seps ...rune) Option {
+	return func(c *Config) {
+		c.Separators.Values = newEscapeSplitter(seps, true, false).Split
+		c.Separators.errs.values = nil
+	}
+}
+
+// SeparateValuesQuotedBy TODO
+// Separators between literal double quotes do not split (e.g. `"a,b",c` =>
+// ["a,b", "c"]), whereas "%22" is taken as user text
+func SeparateValuesQuotedBy(seps ...rune) Option {
+	return func(c *Config) {
+		c.Separators.Values = newEscapeSplitter(seps, false, true).Split
+		c.Separators.errs.values = nil
+	}
+}
+
+// SeparateValuesQuotedEscapedBy TODO
+// As SeparateValuesQuotedBy, additionally with backslashes escaping separators
+// and quotes alike (e.g. `"a\"b",c` => [`a"b`, "c"])
+func SeparateValuesQuotedEscapedBy(seps ...rune) Option {
+	return func(c *Config) {
+		c.Separators.Values = newEscapeSplitter(seps, true, true).Split
+		c.Separators.errs.values = nil
+	}
 }
 
 // ----- Set mode options
@@ -203,6 +612,11 @@ func LogToMarker(marker func(DecodeLevel, string, reflect.Value)) Option {
 	return func(c *Config) { c.LogTrace = TraceMarker(marker) }
 }
 
+// LogToContextMarker TODO
+func LogToContextMarker(marker func(context.Context, DecodeLevel, string, reflect.Value)) Option {
+	return func(c *Config) { c.LogTrace = ContextTraceMarker(marker) }
+}
+
 // LogToStd TODO
 func LogToStd(l *log.Logger) Option {
 	marker := func(level DecodeLevel, input string, target reflect.Value) {
@@ -268,3 +682,20 @@ func StructTagNameAs(name string) Option {
 		c.StructParse.SetTagName = name + configDefaultSetTagSuffix
 	}
 }
+
+// ----- Unmarshal options
+
+// UnmarshalBinaryValues TODO
+func UnmarshalBinaryValues(b bool) Option {
+	return func(c *Config) { c.Unmarshal.Binary = b }
+}
+
+// UnmarshalFlagValues TODO
+func UnmarshalFlagValues(b bool) Option {
+	return func(c *Config) { c.Unmarshal.Flag = b }
+}
+
+// UnmarshalJSONValues TODO
+func UnmarshalJSONValues(b bool) Option {
+	return func(c *Config) { c.Unmarshal.JSON = b }
+}
@@ -1,25 +1,361 @@
 package qry
 
 import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// Special time layouts
+const (
+	TimeLayoutUnix      = "unix"
+	TimeLayoutUnixMilli = "unixmilli"
+)
+
+// Decimal exponents beyond this magnitude make math/big parsing prohibitively
+// expensive (e.g. "1e100000000" into a big.Float), and are thus rejected
+const bigMaxDecimalExponent = 10000
+
 // ConfigConvert TODO
 type ConfigConvert struct {
-	IntegerBase int
-	Unescape    func(string) (string, error)
+	BigFloatMode      big.RoundingMode
+	BigFloatPrecision uint
+	BoolFalse         []string
+	BoolFlag          bool
+	BoolTrue          []string
+	ByteEncoding      string
+	ComplexPairs      bool
+	Empty             EmptyMode
+	IntegerBase       int
+	TimeLayouts       []string
+	TimeLocation      *time.Location
+	Transforms        map[string]func(string) string
+	Types             map[reflect.Type]ConvertType
+	Unescape          func(string) (string, error)
 }
 
-type convertSetter func(string, reflect.Value) error
+func (cc ConfigConvert) validate() error {
+	if err := validateIntegerBase(cc.IntegerBase); err != nil {
+		return err
+	}
+
+	if !cc.Empty.valid() {
+		return fmt.Errorf("invalid empty mode '%s'", cc.Empty)
+	}
+
+	if err := validateBoolWords(cc.BoolTrue, cc.BoolFalse); err != nil {
+		return err
+	}
+
+	if _, ok := byteEncodings[cc.ByteEncoding]; !ok {
+		return fmt.Errorf("invalid byte encoding '%s'", cc.ByteEncoding)
+	}
+
+	if len(cc.TimeLayouts) < 1 {
+		return errors.New("empty time layout list")
+	}
+
+	if cc.TimeLocation == nil {
+		return errors.New("nil time location")
+	}
+
+	for name, transform := range cc.Transforms {
+		if name == "" {
+			return errors.New("empty transform name")
+		}
+
+		if transform == nil {
+			return fmt.Errorf("nil transform function '%s'", name)
+		}
+	}
+
+	for t, ct := range cc.Types {
+		if ct.Set == nil {
+			return fmt.Errorf("nil convert function for type %s", t)
+		}
+
+		for _, level := range ct.Levels {
+			if !level.validInput() {
+				return fmt.Errorf("invalid convert level for type %s: %s", t, level)
+			}
+		}
+	}
+	return nil
+}
+
+func validateIntegerBase(base int) error {
+	// Restrict to bases supported by strconv (a subset of those supported by
+	// math/big, which panics rather than errors on invalid bases)
+	if base != 0 && (base < 2 || base > 36) {
+		return fmt.Errorf("invalid integer base %d", base)
+	}
+	return nil
+}
+
+func validateBoolWords(truthy, falsy []string) error {
+	if len(truthy) < 1 {
+		return errors.New("empty true bool word list")
+	}
+
+	if len(falsy) < 1 {
+		return errors.New("empty false bool word list")
+	}
+
+	words := newBoolWords(truthy, nil)
+	for _, word := range falsy {
+		if words[strings.ToLower(word)] {
+			return fmt.Errorf("bool word '%s' both true and false", word)
+		}
+	}
+	return nil
+}
+
+// boolWords maps lower-cased words to the bool value they represent
+type boolWords map[string]bool
+
+func newBoolWords(truthy, falsy []string) boolWords {
+	res := make(boolWords, len(truthy)+len(falsy))
+	for _, word := range truthy {
+		res[strings.ToLower(word)] = true
+	}
+	for _, word := range falsy {
+		res[strings.ToLower(word)] = false
+	}
+	return res
+}
+
+func (bw boolWords) parse(str string) (bool, error) {
+	b, ok := bw[strings.ToLower(str)]
+	if !ok {
+		// Mirror strconv.ParseBool's error for familiarity
+		return false, &strconv.NumError{Func: "ParseBool", Num: str, Err: strconv.ErrSyntax}
+	}
+	return b, nil
+}
+
+// Unescape function names, as accepted by the `unescape=` base tag directive
+const (
+	UnescapeNone  = "none"
+	UnescapePath  = "path"
+	UnescapeQuery = "query"
+)
+
+var unescapeNames = map[string]func(string) (string, error){
+	UnescapeNone:  func(s string) (string, error) { return s, nil },
+	UnescapePath:  url.PathUnescape,
+	UnescapeQuery: url.QueryUnescape,
+}
+
+// EmptyMode TODO
+// Determines the handling of empty value list and value inputs
+type EmptyMode string
+
+// Empty TODO
+const (
+	// EmptyDecode TODO
+	// Decode the empty input as any other
+	EmptyDecode EmptyMode = "decode"
+
+	// EmptySkip TODO
+	// Leave the target untouched, omitting empty value list elements from
+	// slices and leaving empty value list fields unset (no map entries, etc.)
+	EmptySkip EmptyMode = "skip"
+
+	// EmptyZero TODO
+	// Set the zero value, allocating through any pointers
+	EmptyZero EmptyMode = "zero"
+
+	// EmptyNil TODO
+	// Set the zero value, which is nil for pointers, maps, slices, etc.
+	EmptyNil EmptyMode = "nil"
+
+	// EmptyError TODO
+	EmptyError EmptyMode = "error"
+)
+
+func (em EmptyMode) valid() bool {
+	switch em {
+	case EmptyDecode, EmptySkip, EmptyZero, EmptyNil, EmptyError:
+		return true
+	}
+	return false
+}
+
+// Byte encoding names, as accepted by the `encoding=` base tag directive
+const (
+	ByteEncodingBase64    = "base64"
+	ByteEncodingBase64URL = "base64url"
+	ByteEncodingHex       = "hex"
+	ByteEncodingRaw       = "raw"
+)
+
+var byteEncodings = map[string]func(string) ([]byte, error){
+	// Padding is optional for both base64 alphabets
+	ByteEncodingBase64: func(s string) ([]byte, error) {
+		return base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+	},
+	ByteEncodingBase64URL: func(s string) ([]byte, error) {
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	},
+	ByteEncodingHex: hex.DecodeString,
+	ByteEncodingRaw: func(s string) ([]byte, error) { return []byte(s), nil },
+}
+
+// Built-in transform names, each also accepted as a bare base tag directive
+const (
+	TransformCollapse = "collapse"
+	TransformLower    = "lower"
+	TransformTrim     = "trim"
+	TransformUpper    = "upper"
+)
+
+var builtinTransforms = map[string]func(string) string{
+	TransformCollapse: func(s string) string { return strings.Join(strings.Fields(s), " ") },
+	TransformLower:    strings.ToLower,
+	TransformTrim:     strings.TrimSpace,
+	TransformUpper:    strings.ToUpper,
+}
+
+// ConvertType TODO
+type ConvertType struct {
+	// Levels TODO
+	// An empty list indicates all levels
+	Levels []DecodeLevel
+
+	// Set TODO
+	Set func(string, reflect.Value) error
+}
+
+// convertOptions holds conversion settings that may vary per decode state,
+// defaulting to the decoder-wide config and overridden by struct field tags
+type convertOptions struct {
+	boolFlag     bool
+	byteEncoding func(string) ([]byte, error)
+	empty        EmptyMode
+	integerBase  int
+	timeLayouts  []string
+	transformMap map[string]func(string) string
+	transforms   []func(string) string
+	unescape     func(string) (string, error)
+}
+
+func (co convertOptions) withTag(bti baseTagInfo) convertOptions {
+	res := co
+
+	if bti.TagFlag {
+		res.boolFlag = true
+	}
+
+	if bti.TagByteEncoding != "" {
+		// Validated during struct parsing
+		res.byteEncoding = byteEncodings[bti.TagByteEncoding]
+	}
+
+	if bti.TagEmpty != "" {
+		res.empty = bti.TagEmpty
+	}
+
+	if bti.TagIntegerBase != nil {
+		res.integerBase = *bti.TagIntegerBase
+	}
+
+	if len(bti.TagTransforms) > 0 {
+		// Validated during struct parsing
+		res.transforms = make([]func(string) string, len(bti.TagTransforms))
+		for i, name := range bti.TagTransforms {
+			res.transforms[i] = co.transformMap[name]
+		}
+	}
+
+	if bti.TagUnescape != "" {
+		// Validated during struct parsing
+		res.unescape = unescapeNames[bti.TagUnescape]
+	}
+
+	if len(bti.TagLayouts) > 0 {
+		res.timeLayouts = bti.TagLayouts
+	}
+
+	return res
+}
+
+type convertSetter func(string, reflect.Value, convertOptions) error
+
+type typeConverter struct {
+	levels map[DecodeLevel]bool
+	set    convertSetter
+}
+
+func newTypeConverter(ct ConvertType) typeConverter {
+	res := typeConverter{
+		set: func(str string, val reflect.Value, _ convertOptions) error { return ct.Set(str, val) },
+	}
+
+	if len(ct.Levels) > 0 {
+		res.levels = make(map[DecodeLevel]bool)
+		for _, level := range ct.Levels {
+			res.levels[level] = true
+		}
+	}
+
+	return res
+}
+
+func (tc typeConverter) inScope(level DecodeLevel) bool { return tc.levels == nil || tc.levels[level] }
 
 type converter struct {
 	ConfigConvert
-	kindMap map[reflect.Kind]convertSetter
+	boolWords    boolWords
+	kindMap      map[reflect.Kind]convertSetter
+	transformMap map[string]func(string) string
+	typeMap      map[reflect.Type]typeConverter
 }
 
 func newConverter(cfg ConfigConvert) *converter {
-	res := &converter{ConfigConvert: cfg}
+	res := &converter{
+		ConfigConvert: cfg,
+		boolWords:     newBoolWords(cfg.BoolTrue, cfg.BoolFalse),
+	}
+
+	res.transformMap = make(map[string]func(string) string, len(builtinTransforms)+len(cfg.Transforms))
+	for name, transform := range builtinTransforms {
+		res.transformMap[name] = transform
+	}
+
+	// User-registered transforms override the above built-ins
+	for name, transform := range cfg.Transforms {
+		res.transformMap[name] = transform
+	}
+
+	res.typeMap = map[reflect.Type]typeConverter{
+		reflect.TypeOf(big.Float{}):      {set: res.setBigFloat},
+		reflect.TypeOf(big.Int{}):        {set: res.setBigInt},
+		reflect.TypeOf(big.Rat{}):        {set: res.setBigRat},
+		reflect.TypeOf(time.Time{}):      {set: res.setTime},
+		reflect.TypeOf(time.Duration(0)): {set: res.setDuration},
+
+		reflect.TypeOf(sql.NullBool{}):    {set: res.nullSetter(res.setBool)},
+		reflect.TypeOf(sql.NullByte{}):    {set: res.nullSetter(res.uintSetter(8))},
+		reflect.TypeOf(sql.NullFloat64{}): {set: res.nullSetter(res.floatSetter(64))},
+		reflect.TypeOf(sql.NullInt16{}):   {set: res.nullSetter(res.intSetter(16))},
+		reflect.TypeOf(sql.NullInt32{}):   {set: res.nullSetter(res.intSetter(32))},
+		reflect.TypeOf(sql.NullInt64{}):   {set: res.nullSetter(res.intSetter(64))},
+		reflect.TypeOf(sql.NullString{}):  {set: res.nullSetter(res.setString)},
+		reflect.TypeOf(sql.NullTime{}):    {set: res.nullSetter(res.setTime)},
+	}
+
+	// User-registered types override the above built-ins
+	for t, ct := range cfg.Types {
+		res.typeMap[t] = newTypeConverter(ct)
+	}
 
 	res.kindMap = map[reflect.Kind]convertSetter{
 		reflect.String: res.setString,
@@ -41,39 +377,79 @@ func newConverter(cfg ConfigConvert) *converter {
 		reflect.Float32: res.floatSetter(32),
 		reflect.Float64: res.floatSetter(64),
 
-		// NOTE: bit sizes displayed here are for underlying floats (real half of the complex)
-		reflect.Complex64:  res.complexSetter(32),
-		reflect.Complex128: res.complexSetter(64),
+		reflect.Complex64:  res.complexSetter(64),
+		reflect.Complex128: res.complexSetter(128),
 	}
 
 	return res
 }
 
-func (c *converter) handle(level DecodeLevel, raw string, val reflect.Value) (bool, error) {
+func (c *converter) defaultOptions() convertOptions {
+	return convertOptions{
+		boolFlag:     c.BoolFlag,
+		byteEncoding: byteEncodings[c.ByteEncoding],
+		empty:        c.Empty,
+		integerBase:  c.IntegerBase,
+		timeLayouts:  c.TimeLayouts,
+		transformMap: c.transformMap,
+		unescape:     c.Unescape,
+	}
+}
+
+func (c *converter) checkTransform(name string) bool {
+	_, res := c.transformMap[name]
+	return res
+}
+
+func (c *converter) checkType(t reflect.Type) bool {
+	_, res := c.typeMap[t]
+	return res
+}
+
+func (c *converter) handleType(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+	tc, ok := c.typeMap[val.Type()]
+	if !ok || !tc.inScope(level) {
+		return false, nil
+	}
+
+	return true, c.convert(level, raw, val, state, tc.set)
+}
+
+func (c *converter) handle(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
 	setter, ok := c.kindMap[val.Kind()]
 	if !ok {
 		return false, nil
 	}
 
-	str, err := c.Unescape(raw)
+	return true, c.convert(level, raw, val, state, setter)
+}
+
+func (c *converter) convert(level DecodeLevel, raw string, val reflect.Value, state *DecodeState, setter convertSetter) error {
+	str, err := state.Unescape(raw, val)
 	if err != nil {
-		return true, level.wrapError(err, raw, val)
+		return level.wrapError(err, raw, val)
 	}
 
-	if err = setter(str, val); err != nil {
-		return true, level.wrapError(err, raw, val)
+	if err = setter(str, val, state.convert); err != nil {
+		return level.wrapError(err, raw, val)
 	}
 
-	return true, nil
+	return nil
 }
 
-func (c *converter) setString(str string, val reflect.Value) error {
+func (c *converter) setString(str string, val reflect.Value, _ convertOptions) error {
 	val.SetString(str)
 	return nil
 }
 
-func (c *converter) setBool(str string, val reflect.Value) error {
-	b, err := strconv.ParseBool(str)
+func (c *converter) setBool(str string, val reflect.Value, opts convertOptions) error {
+	if str == "" && opts.boolFlag {
+		// Key present without a value => flag set
+		val.SetBool(true)
+		return nil
+	}
+
+	b, err := c.boolWords.parse(str)
 	if err != nil {
 		return err
 	}
@@ -82,8 +458,8 @@ func (c *converter) setBool(str string, val reflect.Value) error {
 }
 
 func (c *converter) intSetter(bitSize int) convertSetter {
-	return func(str string, val reflect.Value) error {
-		i, err := strconv.ParseInt(str, c.IntegerBase, bitSize)
+	return func(str string, val reflect.Value, opts convertOptions) error {
+		i, err := strconv.ParseInt(str, opts.integerBase, bitSize)
 		if err != nil {
 			return err
 		}
@@ -93,8 +469,8 @@ func (c *converter) intSetter(bitSize int) convertSetter {
 }
 
 func (c *converter) uintSetter(bitSize int) convertSetter {
-	return func(str string, val reflect.Value) error {
-		ui, err := strconv.ParseUint(str, c.IntegerBase, bitSize)
+	return func(str string, val reflect.Value, opts convertOptions) error {
+		ui, err := strconv.ParseUint(str, opts.integerBase, bitSize)
 		if err != nil {
 			return err
 		}
@@ -104,7 +480,7 @@ func (c *converter) uintSetter(bitSize int) convertSetter {
 }
 
 func (c *converter) floatSetter(bitSize int) convertSetter {
-	return func(str string, val reflect.Value) error {
+	return func(str string, val reflect.Value, _ convertOptions) error {
 		f, err := strconv.ParseFloat(str, bitSize)
 		if err != nil {
 			return err
@@ -114,13 +490,157 @@ func (c *converter) floatSetter(bitSize int) convertSetter {
 	}
 }
 
-func (c *converter) complexSetter(floatBitSize int) convertSetter {
-	return func(str string, val reflect.Value) error {
-		f, err := strconv.ParseFloat(str, floatBitSize)
+func (c *converter) complexSetter(bitSize int) convertSetter {
+	return func(str string, val reflect.Value, _ convertOptions) error {
+		cmplx, err := strconv.ParseComplex(str, bitSize)
 		if err != nil {
 			return err
 		}
-		val.SetComplex(complex(f, 0))
+		val.SetComplex(cmplx)
+		return nil
+	}
+}
+
+func checkBigExponent(str string, base int) error {
+	if base != 0 && base != 10 {
+		// Explicit non-decimal base => 'e' is either a digit or invalid
+		return nil
+	}
+
+	trimmed := strings.TrimLeft(str, "+-")
+	if len(trimmed) > 1 && trimmed[0] == '0' && (trimmed[1] == 'x' || trimmed[1] == 'X') {
+		// Hexadecimal => 'e' is a digit and 'p' a (cheap) binary exponent
+		return nil
+	}
+
+	idx := strings.LastIndexAny(str, "eE")
+	if idx < 0 {
+		return nil
+	}
+
+	exp, err := strconv.Atoi(str[idx+1:])
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return errors.New("exponent out of range")
+	case err != nil:
+		// Leave syntax errors to math/big
+		return nil
+	case exp > bigMaxDecimalExponent || exp < -bigMaxDecimalExponent:
+		return errors.New("exponent out of range")
+	}
+
+	return nil
+}
+
+func (c *converter) setBigInt(str string, val reflect.Value, opts convertOptions) error {
+	i, ok := new(big.Int).SetString(str, opts.integerBase)
+	if !ok {
+		return errors.New("invalid big.Int syntax")
+	}
+	dst := val.Addr().Interface().(*big.Int)
+	*dst = big.Int{} // Reset, so Set allocates rather than write in place
+	dst.Set(i)
+	return nil
+}
+
+func (c *converter) setBigFloat(str string, val reflect.Value, opts convertOptions) error {
+	base := opts.integerBase
+	switch base {
+	case 0, 2, 8, 10, 16:
+	default:
+		return fmt.Errorf("invalid big.Float base %d", base)
+	}
+
+	if err := checkBigExponent(str, base); err != nil {
+		return err
+	}
+
+	f, _, err := new(big.Float).SetPrec(c.BigFloatPrecision).SetMode(c.BigFloatMode).Parse(str, base)
+	if err != nil {
+		return err
+	}
+	dst := val.Addr().Interface().(*big.Float)
+	*dst = big.Float{} // Reset, so Set allocates rather than write in place
+	dst.SetPrec(f.Prec()).SetMode(f.Mode()).Set(f)
+	return nil
+}
+
+func (c *converter) setBigRat(str string, val reflect.Value, _ convertOptions) error {
+	if err := checkBigExponent(str, 0); err != nil {
+		return err
+	}
+
+	r, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return errors.New("invalid big.Rat syntax")
+	}
+	dst := val.Addr().Interface().(*big.Rat)
+	*dst = big.Rat{} // Reset, so Set allocates rather than write in place
+	dst.Set(r)
+	return nil
+}
+
+// nullSetter wraps the setter of a database/sql Null* type's value field, with
+// an empty string indicating null (Valid == false)
+func (c *converter) nullSetter(setter convertSetter) convertSetter {
+	return func(str string, val reflect.Value, opts convertOptions) error {
+		if str == "" {
+			val.Set(reflect.Zero(val.Type()))
+			return nil
+		}
+
+		// All Null* types share the layout struct{ <Value> T; Valid bool }
+		if err := setter(str, val.Field(0), opts); err != nil {
+			return err
+		}
+
+		val.FieldByName("Valid").SetBool(true)
 		return nil
 	}
 }
+
+func (c *converter) setDuration(str string, val reflect.Value, _ convertOptions) error {
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	val.SetInt(int64(d))
+	return nil
+}
+
+func (c *converter) setTime(str string, val reflect.Value, opts convertOptions) error {
+	var err error
+
+	for _, layout := range opts.timeLayouts {
+		var t time.Time
+		if t, err = c.parseTime(layout, str); err == nil {
+			val.Set(reflect.ValueOf(t))
+			return nil
+		}
+	}
+
+	if len(opts.timeLayouts) > 1 {
+		return fmt.Errorf("time matches none of the layouts [%s]", strings.Join(opts.timeLayouts, ", "))
+	}
+
+	return err
+}
+
+func (c *converter) parseTime(layout, str string) (time.Time, error) {
+	switch layout {
+	case TimeLayoutUnix:
+		sec, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(sec, 0).In(c.TimeLocation), nil
+	case TimeLayoutUnixMilli:
+		msec, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(msec).In(c.TimeLocation), nil
+	}
+
+	return time.ParseInLocation(layout, str, c.TimeLocation)
+}
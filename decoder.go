@@ -1,17 +1,22 @@
 package qry
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
-	"strings"
 )
 
 // DecodeInfo TODO
 type DecodeInfo struct {
-	Level  DecodeLevel
-	Input  string
-	Target reflect.Value
+	Level    DecodeLevel
+	Input    string
+	KeyChain []string
+	Target   reflect.Value
+}
+
+func newKeyChainInfo(chain []string, input string, target reflect.Value) DecodeInfo {
+	return DecodeInfo{Level: LevelKeyChain, Input: input, KeyChain: chain, Target: target}
 }
 
 func (di DecodeInfo) String() string {
@@ -19,6 +24,17 @@ func (di DecodeInfo) String() string {
 		return "no info"
 	}
 
+	if di.Level == LevelKeyChain {
+		return fmt.Sprintf(
+			"[%s] %q | %q => %s (%s)",
+			di.Level,
+			di.KeyChain,
+			di.Input,
+			di.Target.Type(),
+			di.Target.Kind(),
+		)
+	}
+
 	return fmt.Sprintf(
 		"[%s] %q => %s (%s)",
 		di.Level,
@@ -57,6 +73,7 @@ const (
 	// Return only
 	LevelRoot
 	LevelKeyChain
+	LevelTransform
 )
 
 var decodeLevelNames = map[DecodeLevel]string{
@@ -68,8 +85,9 @@ var decodeLevelNames = map[DecodeLevel]string{
 	LevelValueList: "value list",
 	LevelValue:     "value",
 
-	LevelRoot:     "root",
-	LevelKeyChain: "key chain",
+	LevelRoot:      "root",
+	LevelKeyChain:  "key chain",
+	LevelTransform: "transform",
 }
 
 func (dl DecodeLevel) String() string {
@@ -117,6 +135,14 @@ func (dl DecodeLevel) newInternalError(msg string, input string, target reflect.
 	return dl.wrapError(fmt.Errorf("internal: %w", errors.New(msg)), input, target)
 }
 
+func wrapKeyChainError(err error, chain []string, input string, target reflect.Value) DecodeError {
+	return DecodeError{err: err, DecodeInfo: newKeyChainInfo(chain, input, target)}
+}
+
+func newKeyChainError(msg string, chain []string, input string, target reflect.Value) DecodeError {
+	return wrapKeyChainError(errors.New(msg), chain, input, target)
+}
+
 // Useful for map and interface elements, which are not addressable and thus not settable
 func ensureSettable(val reflect.Value) reflect.Value {
 	res := reflect.New(val.Type()).Elem()
@@ -126,12 +152,16 @@ func ensureSettable(val reflect.Value) reflect.Value {
 
 // Decoder TODO
 type Decoder struct {
+	atomic            bool
 	baseModes         levelModes
 	ignoreInvalidKeys bool
+	limits            ConfigLimits
 	logTrace          Trace
 	separators        ConfigSeparate
 
 	converter    *converter
+	handlers     []Handler
+	hooks        ConfigHook
 	structParser *structParser
 	unmarshaler  *unmarshaler
 }
@@ -142,6 +172,37 @@ func NewDecoder(opts ...Option) (*Decoder, error) { return NewConfig().NewDecode
 // Unescape TODO: friendly.go
 func (d *Decoder) Unescape(s string) (string, error) { return d.converter.Unescape(s) }
 
+// Split TODO: friendly.go
+// Splits raw per the separators of the given level: query => fields, field =>
+// (key, value list) pair, key => key chain, value list => values
+func (d *Decoder) Split(level DecodeLevel, raw string) ([]string, error) {
+	switch level {
+	case LevelQuery:
+		res := d.separators.Fields(raw)
+		if err := d.limits.checkFields(len(res)); err != nil {
+			return nil, err
+		}
+		return res, nil
+	case LevelField:
+		key, valueList := d.separators.KeyVals(raw)
+		return []string{key, valueList}, nil
+	case LevelKey:
+		res := d.splitKeyChain(raw)
+		if err := d.limits.checkKeyChainDepth(len(res)); err != nil {
+			return nil, err
+		}
+		return res, nil
+	case LevelValueList:
+		res := d.separators.Values(raw)
+		if err := d.limits.checkValues(len(res)); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("invalid split level: %s", level)
+}
+
 // DecodeQuery TODO: friendly.go
 func (d *Decoder) DecodeQuery(query string, v interface{}, traces ...Trace) error {
 	return d.Decode(LevelQuery, query, v, traces...)
@@ -167,8 +228,43 @@ func (d *Decoder) DecodeValue(value string, v interface{}, traces ...Trace) erro
 	return d.Decode(LevelValue, value, v, traces...)
 }
 
+// DecodeQueryContext TODO: friendly.go
+func (d *Decoder) DecodeQueryContext(ctx context.Context, query string, v interface{}, traces ...Trace) error {
+	return d.DecodeContext(ctx, LevelQuery, query, v, traces...)
+}
+
+// DecodeFieldContext TODO: friendly.go
+func (d *Decoder) DecodeFieldContext(ctx context.Context, field string, v interface{}, traces ...Trace) error {
+	return d.DecodeContext(ctx, LevelField, field, v, traces...)
+}
+
+// DecodeKeyContext TODO: friendly.go
+func (d *Decoder) DecodeKeyContext(ctx context.Context, key string, v interface{}, traces ...Trace) error {
+	return d.DecodeContext(ctx, LevelKey, key, v, traces...)
+}
+
+// DecodeValueListContext TODO: friendly.go
+func (d *Decoder) DecodeValueListContext(ctx context.Context, valueList string, v interface{}, traces ...Trace) error {
+	return d.DecodeContext(ctx, LevelValueList, valueList, v, traces...)
+}
+
+// DecodeValueContext TODO: friendly.go
+func (d *Decoder) DecodeValueContext(ctx context.Context, value string, v interface{}, traces ...Trace) error {
+	return d.DecodeContext(ctx, LevelValue, value, v, traces...)
+}
+
 // Decode TODO
 func (d *Decoder) Decode(level DecodeLevel, input string, v interface{}, traces ...Trace) error {
+	return d.DecodeContext(context.Background(), level, input, v, traces...)
+}
+
+// DecodeContext TODO
+// A nil ctx is treated as context.Background()
+func (d *Decoder) DecodeContext(ctx context.Context, level DecodeLevel, input string, v interface{}, traces ...Trace) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	val := reflect.ValueOf(v)
 
 	switch {
@@ -180,50 +276,106 @@ func (d *Decoder) Decode(level DecodeLevel, input string, v interface{}, traces
 		return LevelRoot.newError("nil pointer target", input, val)
 	}
 
-	if d.logTrace != nil {
-		traces = append(traces, d.logTrace)
+	if err := d.limits.checkInputLength(len(input)); err != nil {
+		return LevelRoot.wrapError(err, input, val)
 	}
 
-	state := &decodeState{
-		modes: d.baseModes,
-		trace: mergeTraces(traces),
+	traces = append(traces, d.logTrace)
+
+	state := &DecodeState{
+		convert:    d.converter.defaultOptions(),
+		ctx:        ctx,
+		decoder:    d,
+		journal:    newJournal(d.atomic),
+		mapEntries: newMapEntryCounter(d.limits.MapEntries),
+		modes:      d.baseModes,
+		trace:      mergeTraces(ctx, traces),
 	}
 
-	return d.decode(level, input, val.Elem(), state)
+	err := d.decode(level, input, val.Elem(), state)
+	if err != nil {
+		state.journal.rollback()
+	}
+
+	return err
 }
 
-func (d *Decoder) decode(level DecodeLevel, raw string, val reflect.Value, state *decodeState) error {
-	state.trace.Mark(level, raw, val)
+func (d *Decoder) decode(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) error {
+	state.mark(level, raw, val)
 
 	if !val.CanSet() {
 		return level.newInternalError("non-settable target", raw, val)
 	}
 
-	if complete, err := d.handleIndirects(level, raw, val, state); complete {
-		return err
+	state.save(val)
+
+	if raw == "" && (level == LevelValueList || level == LevelValue) && !state.boolFlag(val.Type()) {
+		if complete, err := d.handleEmpty(level, raw, val, state); complete {
+			return err
+		}
 	}
 
-	if complete, err := d.handleLiterals(level, raw, val, state); complete {
-		return err
+	if d.hooks.empty() {
+		return d.handle(level, raw, val, state)
+	}
+
+	return d.handleHooked(level, raw, val, state)
+}
+
+func (d *Decoder) handleEmpty(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+	switch state.convert.empty {
+	case EmptySkip:
+		return true, nil
+	case EmptyZero:
+		setZeroIndirect(val)
+		return true, nil
+	case EmptyNil:
+		val.Set(reflect.Zero(val.Type()))
+		return true, nil
+	case EmptyError:
+		return true, level.newError("empty value", raw, val)
+	}
+
+	return false, nil
+}
+
+// setZeroIndirect sets val to its zero value, allocating through pointers
+func setZeroIndirect(val reflect.Value) {
+	if val.Kind() != reflect.Ptr {
+		val.Set(reflect.Zero(val.Type()))
+		return
 	}
 
-	if complete, err := d.handleContainers(level, raw, val, state); complete {
-		return err
+	ptr := reflect.New(val.Type().Elem())
+	setZeroIndirect(ptr.Elem())
+	val.Set(ptr)
+}
+
+func (d *Decoder) handle(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) error {
+	for _, handler := range d.handlers {
+		if complete, err := handler.Handle(level, raw, val, state); complete {
+			return err
+		}
 	}
 
 	return level.newError("unsupported target type", raw, val)
 }
 
-func (d *Decoder) handleIndirects(level DecodeLevel, raw string, val reflect.Value, state *decodeState) (bool, error) {
+func (d *Decoder) handleIndirects(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
 	shouldReplace := state.modes[level].ReplaceIndirect || val.IsZero()
 
 	switch val.Kind() {
 	case reflect.Ptr:
+		if ptrLoop(val.Type()) {
+			// Recursive pointer type (e.g. type P *P) => no target to decode into
+			return false, nil
+		}
+
 		if shouldReplace {
 			val.Set(reflect.New(val.Type().Elem()))
 		}
 
-		return true, d.decode(level, raw, val.Elem(), state.child())
+		return true, d.decode(level, raw, val.Elem(), state.withFresh(shouldReplace).child())
 
 	case reflect.Interface:
 		var elem reflect.Value
@@ -234,7 +386,7 @@ func (d *Decoder) handleIndirects(level DecodeLevel, raw string, val reflect.Val
 			elem = ensureSettable(val.Elem())
 		}
 
-		if err := d.decode(level, raw, elem, state.child()); err != nil {
+		if err := d.decode(level, raw, elem, state.withFresh(shouldReplace).child()); err != nil {
 			return true, err
 		}
 
@@ -245,16 +397,46 @@ func (d *Decoder) handleIndirects(level DecodeLevel, raw string, val reflect.Val
 	return false, nil
 }
 
-func (d *Decoder) handleLiterals(level DecodeLevel, raw string, val reflect.Value, state *decodeState) (bool, error) {
+// ptrLoop reports whether t is a pointer type whose elements are pointers in
+// perpetuity, checked without allocation as it precedes every pointer decode
+func ptrLoop(t reflect.Type) bool {
+	for slow, fast := t, t; fast.Kind() == reflect.Ptr && fast.Elem().Kind() == reflect.Ptr; {
+		if slow, fast = slow.Elem(), fast.Elem().Elem(); slow == fast {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Decoder) handleLiterals(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+	// Check for qry unmarshalers
+	if complete, err := d.unmarshaler.handleQry(level, raw, val, state); complete {
+		return true, err
+	}
+
+	if val.CanAddr() {
+		if complete, err := d.unmarshaler.handleQry(level, raw, val.Addr(), state); complete {
+			return true, err
+		}
+	}
+
 	// Check for unmarshalers
-	if complete, err := d.unmarshaler.handle(level, raw, val); complete {
+	if complete, err := d.unmarshaler.handle(level, raw, val, state); complete {
 		return true, err
 	}
 
 	// TODO: Given the CanSet() check/heuristic inherant in decode(...), is there
 	// any actual need for this CanAddr() check? (settable ==impies=> addressable, no?)
 	if val.CanAddr() {
-		if complete, err := d.unmarshaler.handle(level, raw, val.Addr()); complete {
+		if complete, err := d.unmarshaler.handle(level, raw, val.Addr(), state); complete {
+			return true, err
+		}
+	}
+
+	// Check for complex numbers given as a (real, imaginary) value list pair,
+	// which is a container of sorts and thus not subject to AllowLiteral
+	if level == LevelValueList && d.converter.ComplexPairs {
+		if complete, err := d.handleComplexPair(raw, val, state); complete {
 			return true, err
 		}
 	}
@@ -265,7 +447,7 @@ func (d *Decoder) handleLiterals(level DecodeLevel, raw string, val reflect.Valu
 	}
 
 	// Try direct conversion to basic types
-	if complete, err := d.converter.handle(level, raw, val); complete {
+	if complete, err := d.converter.handle(level, raw, val, state); complete {
 		return true, err
 	}
 
@@ -273,7 +455,45 @@ func (d *Decoder) handleLiterals(level DecodeLevel, raw string, val reflect.Valu
 	return d.handleFauxLiterals(level, raw, val, state)
 }
 
-func (d *Decoder) handleFauxLiterals(level DecodeLevel, raw string, val reflect.Value, state *decodeState) (bool, error) {
+func (d *Decoder) handleComplexPair(raw string, val reflect.Value, state *DecodeState) (bool, error) {
+	var partType reflect.Type
+
+	switch val.Kind() {
+	case reflect.Complex64:
+		partType = reflect.TypeOf(float32(0))
+	case reflect.Complex128:
+		partType = reflect.TypeOf(float64(0))
+	default:
+		return false, nil
+	}
+
+	rawItems, err := d.splitValues(raw, val, state)
+	if err != nil {
+		return true, err
+	}
+
+	if len(rawItems) != 2 {
+		return true, LevelValueList.newError("complex value list not a (real, imaginary) pair", raw, val)
+	}
+
+	var (
+		realPart = reflect.New(partType).Elem()
+		imagPart = reflect.New(partType).Elem()
+	)
+
+	if err := d.decode(LevelValue, rawItems[0], realPart, state.child()); err != nil {
+		return true, err
+	}
+
+	if err := d.decode(LevelValue, rawItems[1], imagPart, state.child()); err != nil {
+		return true, err
+	}
+
+	val.SetComplex(complex(realPart.Float(), imagPart.Float()))
+	return true, nil
+}
+
+func (d *Decoder) handleFauxLiterals(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
 	kind := val.Kind()
 
 	// Here we're only interested in slices/arrays of ...
@@ -291,8 +511,8 @@ func (d *Decoder) handleFauxLiterals(level DecodeLevel, raw string, val reflect.
 		return false, nil
 	}
 
-	// Don't stomp on user-defined unmarshaling functions
-	if d.unmarshaler.check(elemType) || d.unmarshaler.check(reflect.PtrTo(elemType)) {
+	// Don't stomp on user-defined unmarshaling or conversion functions
+	if d.structParser.checkUnmarshaler(elemType) || d.structParser.checkUnmarshaler(reflect.PtrTo(elemType)) {
 		// HEURISTIC:
 		// This PtrTo check relies on the (correct) assumption that any container
 		// handler for reflect.Slice or reflect.Array will create new (valid)
@@ -300,7 +520,7 @@ func (d *Decoder) handleFauxLiterals(level DecodeLevel, raw string, val reflect.
 		return false, nil
 	}
 
-	str, err := d.converter.Unescape(raw)
+	str, err := state.Unescape(raw, val)
 	if err != nil {
 		return true, level.wrapError(err, raw, val)
 	}
@@ -309,7 +529,12 @@ func (d *Decoder) handleFauxLiterals(level DecodeLevel, raw string, val reflect.
 
 	switch elemKind {
 	case reflect.Uint8:
-		srcVal = reflect.ValueOf([]byte(str))
+		// Decoded prior to any array length check below
+		b, decodeErr := state.convert.byteEncoding(str)
+		if decodeErr != nil {
+			return true, level.wrapError(decodeErr, raw, val)
+		}
+		srcVal = reflect.ValueOf(b)
 	case reflect.Int32:
 		srcVal = reflect.ValueOf([]rune(str))
 	default:
@@ -341,7 +566,7 @@ func (d *Decoder) handleFauxLiterals(level DecodeLevel, raw string, val reflect.
 	return true, nil
 }
 
-func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Value, state *decodeState) (bool, error) {
+func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
 	shouldReplace := state.modes[level].ReplaceContainer || val.IsZero()
 
 	switch val.Kind() {
@@ -349,18 +574,24 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 		var (
 			childLevel DecodeLevel
 			rawItems   []string
+			splitErr   error
 		)
 
 		switch level {
 		case LevelQuery:
-			childLevel, rawItems = LevelField, d.separators.Fields(raw)
+			childLevel = LevelField
+			rawItems, splitErr = d.splitFields(raw, val)
 		case LevelValueList:
-			childLevel, rawItems = LevelValue, d.separators.Values(raw)
+			childLevel, rawItems, splitErr = d.splitValueList(raw, val, state)
 		default:
 			// Only query and value list levels support slices
 			return false, nil
 		}
 
+		if splitErr != nil {
+			return true, splitErr
+		}
+
 		var (
 			elemType = val.Type().Elem()
 			newSlice reflect.Value
@@ -374,8 +605,16 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 		}
 
 		for _, rawItem := range rawItems {
+			if err := state.checkContext(); err != nil {
+				return true, level.wrapError(err, raw, val)
+			}
+
+			if state.skipEmpty(rawItem) {
+				continue
+			}
+
 			newElem := reflect.New(elemType).Elem()
-			if err := d.decode(childLevel, rawItem, newElem, state.child()); err != nil {
+			if err := d.decode(childLevel, rawItem, newElem, state.withFresh(true).childElem()); err != nil {
 				return true, err
 			}
 			newSlice = reflect.Append(newSlice, newElem)
@@ -388,18 +627,30 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 		var (
 			childLevel DecodeLevel
 			rawItems   []string
+			splitErr   error
 		)
 
 		switch level {
 		case LevelQuery:
-			childLevel, rawItems = LevelField, d.separators.Fields(raw)
+			childLevel = LevelField
+			rawItems, splitErr = d.splitFields(raw, val)
+		case LevelKey:
+			if !d.compositeKeys() {
+				return false, nil
+			}
+			childLevel = d.keyComponentLevel(val.Type().Elem())
+			rawItems, splitErr = d.splitKeyComponents(raw, val)
 		case LevelValueList:
-			childLevel, rawItems = LevelValue, d.separators.Values(raw)
+			childLevel, rawItems, splitErr = d.splitValueList(raw, val, state)
 		default:
-			// Only query and value list levels support arrays
+			// Only query, key and value list levels support arrays
 			return false, nil
 		}
 
+		if splitErr != nil {
+			return true, splitErr
+		}
+
 		// TODO: While there's certainly no reasonable way to do anything but
 		// replace the entire array (how to choose what index to begin writing
 		// from otherwise?), would it be more correct to return an error if the
@@ -415,7 +666,11 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 		newArray := reflect.New(val.Type()).Elem()
 
 		for i, rawItem := range rawItems {
-			if err := d.decode(childLevel, rawItem, newArray.Index(i), state.child()); err != nil {
+			if err := state.checkContext(); err != nil {
+				return true, level.wrapError(err, raw, val)
+			}
+
+			if err := d.decode(childLevel, rawItem, newArray.Index(i), state.withFresh(true).childElem()); err != nil {
 				return true, err
 			}
 		}
@@ -429,7 +684,18 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 			return false, nil
 		}
 
-		var dstMap reflect.Value
+		if level == LevelField && state.convert.empty == EmptySkip {
+			if _, rawValueList := d.separators.KeyVals(raw); rawValueList == "" {
+				// Skip the field entirely, prior to creating the map
+				return true, nil
+			}
+		}
+
+		var (
+			dstMap   reflect.Value
+			mapState = state.withFresh(shouldReplace)
+		)
+
 		if shouldReplace {
 			dstMap = reflect.MakeMap(val.Type())
 		} else {
@@ -439,13 +705,22 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 		switch level {
 		case LevelQuery:
 			// Query level supports key chaining => use decodeKeyChain(...)
-			for _, rawField := range d.separators.Fields(raw) {
+			rawFields, splitErr := d.splitFields(raw, val)
+			if splitErr != nil {
+				return true, splitErr
+			}
+
+			for _, rawField := range rawFields {
+				if err := state.checkContext(); err != nil {
+					return true, level.wrapError(err, raw, val)
+				}
+
 				var (
 					rawKey, rawValueList = d.separators.KeyVals(rawField)
-					rawKeyChain          = d.separators.KeyChain(rawKey)
+					rawKeyChain          = d.splitKeyChain(rawKey)
 				)
 
-				if err := d.decodeKeyChain(rawKeyChain, rawValueList, dstMap, state.child()); err != nil {
+				if err := d.decodeKeyChain(rawKeyChain, rawValueList, dstMap, mapState.child()); err != nil {
 					return true, err
 				}
 			}
@@ -456,22 +731,29 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 				rawKey, rawValueList = d.separators.KeyVals(raw)
 			)
 
-			if err := d.decode(LevelKey, rawKey, newKey, state.child()); err != nil {
+			if err := d.decode(LevelKey, rawKey, newKey, state.withFresh(true).child()); err != nil {
 				return true, err
 			}
 
 			elem := dstMap.MapIndex(newKey)
-			if !elem.IsValid() {
+			exists := elem.IsValid()
+
+			if !exists {
 				// Map does not contain newKey
+				if err := state.mapEntries.add(); err != nil {
+					return true, level.wrapError(err, raw, val)
+				}
+
 				elem = reflect.New(val.Type().Elem()).Elem()
 			} else {
 				elem = ensureSettable(elem)
 			}
 
-			if err := d.decode(LevelValueList, rawValueList, elem, state.child()); err != nil {
+			if err := d.decode(LevelValueList, rawValueList, elem, mapState.withFresh(!exists).child()); err != nil {
 				return true, err
 			}
 
+			mapState.saveMapIndex(dstMap, newKey)
 			dstMap.SetMapIndex(newKey, elem)
 		}
 
@@ -482,12 +764,20 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 		return true, nil
 
 	case reflect.Struct:
+		if level == LevelValueList || level == LevelKey {
+			// Key and value list levels support structs as positional tuples
+			return d.handleTuple(level, raw, val, state, shouldReplace)
+		}
+
 		if level != LevelQuery && level != LevelField {
-			// Only query and field levels support structs
+			// Only query, field, key and value list levels support structs
 			return false, nil
 		}
 
-		var dstStruct reflect.Value
+		var (
+			dstStruct   reflect.Value
+			structState = state.withFresh(shouldReplace)
+		)
 
 		if shouldReplace {
 			// TODO: We don't really need to create a new struct if val.IsZero() is true here
@@ -499,20 +789,29 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 		switch level {
 		case LevelQuery:
 			// Query level supports key chaining => use decodeKeyChain(...)
-			for _, rawField := range d.separators.Fields(raw) {
+			rawFields, splitErr := d.splitFields(raw, val)
+			if splitErr != nil {
+				return true, splitErr
+			}
+
+			for _, rawField := range rawFields {
+				if err := state.checkContext(); err != nil {
+					return true, level.wrapError(err, raw, val)
+				}
+
 				var (
 					rawKey, rawValueList = d.separators.KeyVals(rawField)
-					rawKeyChain          = d.separators.KeyChain(rawKey)
+					rawKeyChain          = d.splitKeyChain(rawKey)
 				)
 
-				if err := d.decodeKeyChain(rawKeyChain, rawValueList, dstStruct, state.child()); err != nil {
+				if err := d.decodeKeyChain(rawKeyChain, rawValueList, dstStruct, structState.child()); err != nil {
 					return true, err
 				}
 			}
 
 		case LevelField:
 			// Field level does NOT support key chaining => decode directly into key/valueList levels
-			items, parseErr := d.structParser.parse(dstStruct)
+			items, parseErr := d.structParser.parse(dstStruct, structState.save)
 			if parseErr != nil {
 				return true, level.wrapError(parseErr, raw, val)
 			}
@@ -521,7 +820,7 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 
 			// TODO: magic => constant
 			if item, ok := items["key"]; ok {
-				childState := state.childWithSetOpts(item.SetOptions(LevelKey))
+				childState := structState.childWithItem(item, LevelKey)
 				if err := d.decode(LevelKey, rawKey, item.val, childState); err != nil {
 					return true, err
 				}
@@ -529,7 +828,7 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 
 			// TODO: magic => constant
 			if item, ok := items["values"]; ok {
-				childState := state.childWithSetOpts(item.SetOptions(LevelValueList))
+				childState := structState.childWithItem(item, LevelValueList)
 				if err := d.decode(LevelValueList, rawValueList, item.val, childState); err != nil {
 					return true, err
 				}
@@ -546,52 +845,344 @@ func (d *Decoder) handleContainers(level DecodeLevel, raw string, val reflect.Va
 	return false, nil
 }
 
-func (d *Decoder) decodeKeyChain(rawChain []string, raw string, val reflect.Value, state *decodeState) error {
+func (d *Decoder) handleTuple(level DecodeLevel, raw string, val reflect.Value, state *DecodeState, shouldReplace bool) (bool, error) {
+	if level == LevelKey && !d.compositeKeys() {
+		return false, nil
+	}
+
+	var (
+		dstStruct   reflect.Value
+		structState = state.withFresh(shouldReplace)
+	)
+
+	if shouldReplace {
+		dstStruct = reflect.New(val.Type()).Elem()
+	} else {
+		dstStruct = val
+	}
+
+	items, err := d.structParser.parseTuple(dstStruct, structState.save)
+	if err != nil {
+		return true, level.wrapError(err, raw, val)
+	}
+
+	if len(items) < 1 {
+		// No fields to fill positionally => not a tuple
+		return false, nil
+	}
+
+	var rawItems []string
+	if level == LevelKey {
+		rawItems, err = d.splitKeyComponents(raw, val)
+	} else {
+		rawItems, err = d.splitValues(raw, val, state)
+	}
+
+	if err != nil {
+		return true, err
+	}
+
+	if len(rawItems) > len(items) {
+		return true, level.newError("too many values for struct", raw, val)
+	}
+
+	for i, item := range items {
+		if i >= len(rawItems) {
+			if !item.optional() {
+				return true, level.wrapError(fmt.Errorf("missing value for struct field '%s'", item.name), raw, val)
+			}
+			continue
+		}
+
+		if err := state.checkContext(); err != nil {
+			return true, level.wrapError(err, raw, val)
+		}
+
+		childLevel := LevelValue
+		if level == LevelKey {
+			childLevel = d.keyComponentLevel(item.val.Type())
+		}
+
+		if err := d.decode(childLevel, rawItems[i], item.val, structState.childWithItem(item, childLevel)); err != nil {
+			return true, err
+		}
+	}
+
+	if shouldReplace {
+		val.Set(dstStruct)
+	}
+
+	return true, nil
+}
+
+func (d *Decoder) splitFields(raw string, val reflect.Value) ([]string, error) {
+	res, err := d.Split(LevelQuery, raw)
+	if err != nil {
+		return nil, LevelQuery.wrapError(err, raw, val)
+	}
+	return res, nil
+}
+
+func (d *Decoder) splitValues(raw string, val reflect.Value, state *DecodeState) ([]string, error) {
+	res, err := state.Split(LevelValueList, raw)
+	if err != nil {
+		return nil, LevelValueList.wrapError(err, raw, val)
+	}
+	return res, nil
+}
+
+// splitValueList splits raw per the nested value separators if val is a value
+// list of value lists (with sufficient separators configured), otherwise per the
+// values separator. A value separator override replaces the separator of the
+// outermost list only
+func (d *Decoder) splitValueList(raw string, val reflect.Value, state *DecodeState) (DecodeLevel, []string, error) {
+	var (
+		nested = d.separators.NestedValues
+		dims   int
+	)
+
+	if len(nested) > 0 || state.valueSep != nil {
+		// Dimensions only matter given separators beyond the values separator
+		dims = d.valueListDims(val.Type())
+	}
+
+	if state.valueSep != nil {
+		res, err := d.splitValues(raw, val, state)
+		if dims > 1 {
+			return LevelValueList, res, err
+		}
+		return LevelValue, res, err
+	}
+
+	if dims > 1 && dims-1 <= len(nested) {
+		res := nested[len(nested)-(dims-1)](raw)
+		if err := d.limits.checkValues(len(res)); err != nil {
+			return LevelValueList, nil, LevelValueList.wrapError(err, raw, val)
+		}
+		return LevelValueList, res, nil
+	}
+
+	res, err := d.splitValues(raw, val, state)
+	return LevelValue, res, err
+}
+
+// valueListDims counts the nested slice/array dimensions of t, excluding those
+// decoded as a whole (faux literals, unmarshalers, etc.)
+func (d *Decoder) valueListDims(t reflect.Type) int {
+	var res int
+
+	// Guard against recursive types, e.g. type T []T or type P *P
+	for seen := make(map[reflect.Type]bool); !seen[t]; {
+		seen[t] = true
+
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+			continue
+		}
+
+		if d.structParser.checkUnmarshaler(t) || d.structParser.checkUnmarshaler(reflect.PtrTo(t)) {
+			return res
+		}
+
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array:
+		case reflect.Struct:
+			// Positional tuple
+			return res + 1
+		default:
+			return res
+		}
+
+		elemType := t.Elem()
+		if elemKind := elemType.Kind(); elemKind == reflect.Uint8 || elemKind == reflect.Int32 {
+			// Faux literal (byte/rune slice or array), per handleFauxLiterals(...)
+			if !d.structParser.checkUnmarshaler(elemType) && !d.structParser.checkUnmarshaler(reflect.PtrTo(elemType)) {
+				return res
+			}
+		}
+
+		res, t = res+1, elemType
+	}
+	return res
+}
+
+func (d *Decoder) splitKeyComponents(raw string, val reflect.Value) ([]string, error) {
+	res := d.separators.KeyComponents(raw)
+	if err := d.limits.checkValues(len(res)); err != nil {
+		return nil, LevelKey.wrapError(err, raw, val)
+	}
+	return res, nil
+}
+
+// keyComponentLevel returns the level at which to decode a composite key
+// component of type t: value list if itself a value list (e.g. an array or
+// tuple struct), otherwise value
+// compositeKeys reports whether (array or struct) keys may be decoded from key
+// components. No key component separator => composite keys unsupported
+func (d *Decoder) compositeKeys() bool { return d.separators.KeyComponents != nil }
+
+func (d *Decoder) keyComponentLevel(t reflect.Type) DecodeLevel {
+	if d.valueListDims(t) > 0 {
+		return LevelValueList
+	}
+	return LevelValue
+}
+
+func (d *Decoder) splitKeyChain(rawKey string) []string {
+	// An empty chain would have decodeKeyChain(...) target the container
+	// itself, so treat a key that splits into nothing (e.g. "" or ".") as a
+	// single empty key, consistent with the no-op key chain separator
+	if res := d.separators.KeyChain(rawKey); len(res) > 0 {
+		return res
+	}
+	return []string{""}
+}
+
+// chainSepItem finds the item with a chain separator splitting its own name from
+// the start of rawKey, e.g. "nested/inner" given `qry:"nested,chainSep=/"`, for
+// keys not already split by the decoder-wide key chain separator. The longest
+// such name wins, returned along with the remainder of rawKey as split.
+func (d *Decoder) chainSepItem(rawKey string, items map[string]structItem) (structItem, []string, bool) {
+	var (
+		res      structItem
+		resName  string
+		resChain []string
+		found    bool
+	)
+
+	for name, item := range items {
+		if item.TagChainSeparator == "" || (found && len(name) <= len(resName)) {
+			continue
+		}
+
+		chain := newStringSeparators(item.TagChainSeparator).Split(rawKey)
+		if len(chain) < 2 {
+			continue
+		}
+
+		if unescaped, err := d.converter.Unescape(chain[0]); err != nil || unescaped != name {
+			continue
+		}
+
+		res, resName, resChain, found = item, name, chain[1:], true
+	}
+
+	return res, resChain, found
+}
+
+// keyChainHasStruct reports whether a key chain into t may index a struct,
+// through any pointers and map elements
+func keyChainHasStruct(t reflect.Type) bool {
+	// Guard against recursive types, e.g. type M map[string]M
+	for seen := make(map[reflect.Type]bool); !seen[t]; t = t.Elem() {
+		seen[t] = true
+
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Map:
+		case reflect.Struct:
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// keyChainTarget returns the type targeted by a key chain of the given depth
+// into t, through any pointers and map elements
+func keyChainTarget(t reflect.Type, depth int) reflect.Type {
+	// Guard against recursive pointer types, e.g. type P *P
+	seen := make(map[reflect.Type]bool)
+
+	for depth > 0 && !seen[t] {
+		switch t.Kind() {
+		case reflect.Ptr:
+			seen[t] = true
+		case reflect.Map:
+			seen, depth = make(map[reflect.Type]bool), depth-1
+		default:
+			return t
+		}
+		t = t.Elem()
+	}
+	return t
+}
+
+func (d *Decoder) decodeKeyChain(rawChain []string, raw string, val reflect.Value, state *DecodeState) error {
+	// Skip the field entirely, prior to creating any map entries, pointers, etc.
+	// along the chain. Deferred given a struct along the chain, as the empty
+	// mode may yet be overridden by a field tag, or a bool flag target
+	if state.skipEmpty(raw) && !keyChainHasStruct(val.Type()) && !state.boolFlag(keyChainTarget(val.Type(), len(rawChain))) {
+		return nil
+	}
+
 	// Shuttle work off to decode() once key chain is exhausted
 	if len(rawChain) < 1 {
-		// Note this check preceeds state.trace.Mark(...), thus eschewing
+		// Note this check preceeds state.markKeyChain(...), thus eschewing
 		// state.child() is intentional
 		return d.decode(LevelValueList, raw, val, state)
 	}
 
-	// TODO: We're breaking the nice structured nature of DecodeInfo with this
-	// custom string formatting of "input"
-	//
-	// Also doing unneeded format work when trace is a no-op. Need to reconsider
-	// just checking for nil rather than a noop function for "no trace"
-	inputStr := fmt.Sprintf("%s | %s", strings.Join(rawChain, ", "), raw)
+	state.markKeyChain(rawChain, raw, val)
 
-	state.trace.Mark(LevelKeyChain, inputStr, val)
+	if err := state.checkContext(); err != nil {
+		return wrapKeyChainError(err, rawChain, raw, val)
+	}
+
+	state.save(val)
+
+	if err := d.limits.checkKeyChainDepth(state.chainDepth + len(rawChain)); err != nil {
+		return wrapKeyChainError(err, rawChain, raw, val)
+	}
 
 	kind := val.Kind()
 
 	if kind == reflect.Ptr {
-		if val.IsNil() {
-			val.Set(reflect.New(val.Type().Elem()))
+		if !val.IsNil() {
+			return d.decodeKeyChain(rawChain, raw, val.Elem(), state.child())
 		}
 
-		return d.decodeKeyChain(rawChain, raw, val.Elem(), state.child())
+		ptr := reflect.New(val.Type().Elem())
+		if err := d.decodeKeyChain(rawChain, raw, ptr.Elem(), state.withFresh(true).child()); err != nil {
+			return err
+		}
+
+		// Leave the pointer nil when skipping, unless a field tag along the
+		// chain overrode the empty mode
+		if !state.skipEmpty(raw) || !ptr.Elem().IsZero() {
+			val.Set(ptr)
+		}
+		return nil
 	}
 
 	rawKey, remainingChain := rawChain[0], rawChain[1:]
 
 	if kind == reflect.Map {
 		var (
-			valType = val.Type()
-			newKey  = reflect.New(valType.Key()).Elem()
+			valType  = val.Type()
+			newKey   = reflect.New(valType.Key()).Elem()
+			isZero   = val.IsZero()
+			mapState = state.withFresh(isZero)
+			m        = val
 		)
 
-		if val.IsZero() {
-			val.Set(reflect.MakeMap(valType))
+		if isZero {
+			m = reflect.MakeMap(valType)
 		}
 
-		if err := d.decode(LevelKey, rawKey, newKey, state.child()); err != nil {
+		if err := d.decode(LevelKey, rawKey, newKey, state.withFresh(true).child()); err != nil {
 			return err
 		}
 
-		elem := val.MapIndex(newKey)
-		if !elem.IsValid() {
+		elem := m.MapIndex(newKey)
+		exists := elem.IsValid()
+
+		if !exists {
 			// Map does not contain newKey
+			if err := state.mapEntries.add(); err != nil {
+				return wrapKeyChainError(err, rawChain, raw, val)
+			}
+
 			elem = reflect.New(valType.Elem()).Elem()
 		} else {
 			// NOTE/TODO?
@@ -604,39 +1195,73 @@ func (d *Decoder) decodeKeyChain(rawChain []string, raw string, val reflect.Valu
 			elem = ensureSettable(elem)
 		}
 
-		if err := d.decodeKeyChain(remainingChain, raw, elem, state.child()); err != nil {
+		elemState := mapState.withFresh(!exists).child()
+		elemState.chainDepth++
+
+		if err := d.decodeKeyChain(remainingChain, raw, elem, elemState); err != nil {
 			return err
 		}
 
-		val.SetMapIndex(newKey, elem)
+		// Create no entry (nor map) when skipping, unless a field tag along the
+		// chain overrode the empty mode
+		if !exists && state.skipEmpty(raw) && elem.IsZero() {
+			return nil
+		}
+
+		mapState.saveMapIndex(m, newKey)
+		m.SetMapIndex(newKey, elem)
+
+		if isZero {
+			val.Set(m)
+		}
 		return nil
 	}
 
 	if kind == reflect.Struct {
 		unescapedKey, unescapeErr := d.converter.Unescape(rawKey)
 		if unescapeErr != nil {
-			return LevelKeyChain.wrapError(unescapeErr, inputStr, val)
+			return wrapKeyChainError(unescapeErr, rawChain, raw, val)
 		}
 
 		// TODO:
 		// Struct info caching was already a priority, but the fact that this
 		// parse() call now occurs within the "for field in fields..." loop
 		// rather than outside it exacerbates the necessity.
-		items, parseErr := d.structParser.parse(val)
+		items, parseErr := d.structParser.parse(val, state.save)
 		if parseErr != nil {
-			return LevelKeyChain.wrapError(parseErr, inputStr, val)
+			return wrapKeyChainError(parseErr, rawChain, raw, val)
+		}
+
+		var (
+			item, exists = items[unescapedKey]
+			resplit      []string
+		)
+
+		if !exists {
+			item, resplit, exists = d.chainSepItem(rawKey, items)
 		}
 
-		item, exists := items[unescapedKey]
 		if !exists {
 			if d.ignoreInvalidKeys {
 				return nil
 			}
 
-			return LevelKeyChain.newError("unknown key", inputStr, val)
+			return newKeyChainError("unknown key", rawChain, raw, val)
+		}
+
+		childState := state.childWithItem(item, LevelValueList)
+		childState.chainDepth++
+
+		if item.TagChainSeparator != "" {
+			// Further split the remaining chain per the field's own separator,
+			// the depth of the result being checked by the recursion below
+			for _, rawKey := range remainingChain {
+				resplit = append(resplit, childState.chainSep(rawKey)...)
+			}
+
+			remainingChain = resplit
 		}
 
-		childState := state.childWithSetOpts(item.SetOptions(LevelValueList))
 		return d.decodeKeyChain(remainingChain, raw, item.val, childState)
 	}
 
@@ -644,5 +1269,5 @@ func (d *Decoder) decodeKeyChain(rawChain []string, raw string, val reflect.Valu
 		return nil
 	}
 
-	return LevelKeyChain.newError("non-indexable key chain target", inputStr, val)
+	return newKeyChainError("non-indexable key chain target", rawChain, raw, val)
 }
@@ -47,6 +47,22 @@ func queryErrorTests(t *testing.T) {
 	})
 
 	t.Run("key chain", suite.runKeyChainTests)
+	t.Run("limit", suite.runLimitQueryTests)
+	t.Run("context", suite.runContextTests)
+	t.Run("atomic", suite.runAtomicTests)
+	t.Run("convert tag", suite.runConvertTagQueryTests)
+	t.Run("bool", suite.runBoolQueryTests)
+	t.Run("transform", suite.runTransformQueryTests)
+	t.Run("byte encoding", suite.runByteEncodingQueryTests)
+	t.Run("unmarshal opt-in", suite.runUnmarshalOptInQueryTests)
+	t.Run("qry unmarshaler", suite.runQryUnmarshalerQueryTests)
+	t.Run("handler", suite.runHandlerQueryTests)
+	t.Run("optional", suite.runOptionalQueryTests)
+	t.Run("empty", suite.runEmptyQueryTests)
+	t.Run("separate tag", suite.runSeparateTagQueryTests)
+	t.Run("nested list", suite.runNestedListQueryTests)
+	t.Run("composite key", suite.runCompositeKeyQueryTests)
+	t.Run("trace", suite.runTraceQueryTests)
 }
 
 func fieldErrorTests(t *testing.T) {
@@ -83,6 +99,8 @@ func keyErrorTests(t *testing.T) {
 		suite.runUnsupportedListTests(t)
 		suite.runUnsupportedKeyValTests(t)
 	})
+
+	t.Run("qry unmarshaler", suite.runQryUnmarshalerKeyTests)
 }
 
 func valueListErrorTests(t *testing.T) {
@@ -102,6 +120,15 @@ func valueListErrorTests(t *testing.T) {
 	t.Run("container", func(t *testing.T) {
 		t.Run("list", func(t *testing.T) { suite.runListTests(t, ",") })
 	})
+
+	t.Run("complex pair", suite.runComplexPairTests)
+	t.Run("convert type", suite.runConvertTypeValueListTests)
+	t.Run("time", suite.runTimeValueListTests)
+	t.Run("bool", suite.runBoolValueListTests)
+	t.Run("handler", suite.runHandlerValueListTests)
+	t.Run("hook", suite.runHookValueListTests)
+	t.Run("nested list", suite.runNestedListValueListTests)
+	t.Run("tuple", suite.runTupleValueListTests)
 }
 
 func valueErrorTests(t *testing.T) {
@@ -113,6 +140,31 @@ func valueErrorTests(t *testing.T) {
 		suite.runUnsupportedListTests(t)
 		suite.runUnsupportedKeyValTests(t)
 	})
+
+	t.Run("convert type", suite.runConvertTypeValueTests)
+	t.Run("time", suite.runTimeValueTests)
+	t.Run("big", suite.runBigValueTests)
+	t.Run("byte encoding", suite.runByteEncodingValueTests)
+	t.Run("unmarshal opt-in", suite.runUnmarshalOptInValueTests)
+	t.Run("qry unmarshaler", suite.runQryUnmarshalerValueTests)
+	t.Run("hook", suite.runHookValueTests)
+	t.Run("sql null", suite.runSQLNullValueTests)
+}
+
+// ===== Config
+func TestConfig(t *testing.T) {
+	suite := configErrorSuite{}
+
+	t.Run("convert type", suite.runConvertTypeTests)
+	t.Run("time", suite.runTimeTests)
+	t.Run("big", suite.runBigTests)
+	t.Run("bool", suite.runBoolTests)
+	t.Run("transform", suite.runTransformTests)
+	t.Run("byte encoding", suite.runByteEncodingTests)
+	t.Run("handler", suite.runHandlerTests)
+	t.Run("hook", suite.runHookTests)
+	t.Run("empty", suite.runEmptyTests)
+	t.Run("separate regexp", suite.runSeparateRegexpTests)
 }
 
 // ===== Success
@@ -169,6 +221,30 @@ func runQuerySuccessTests(t *testing.T) {
 	})
 
 	t.Run("key chain", suite.runKeyChainTests)
+	t.Run("limit", suite.runLimitQueryTests)
+	t.Run("atomic", suite.runAtomicTests)
+	t.Run("convert type", suite.runConvertTypeQueryTests)
+	t.Run("time", suite.runTimeQueryTests)
+	t.Run("big", suite.runBigQueryTests)
+	t.Run("convert tag", suite.runConvertTagQueryTests)
+	t.Run("bool", suite.runBoolQueryTests)
+	t.Run("transform", suite.runTransformQueryTests)
+	t.Run("byte encoding", suite.runByteEncodingQueryTests)
+	t.Run("unmarshal opt-in", suite.runUnmarshalOptInQueryTests)
+	t.Run("qry unmarshaler", suite.runQryUnmarshalerQueryTests)
+	t.Run("handler", suite.runHandlerQueryTests)
+	t.Run("hook", suite.runHookQueryTests)
+	t.Run("sql null", suite.runSQLNullQueryTests)
+	t.Run("optional", suite.runOptionalQueryTests)
+	t.Run("empty", suite.runEmptyQueryTests)
+	t.Run("separate escaped", suite.runSeparateEscapedQueryTests)
+	t.Run("separate string", suite.runSeparateStringQueryTests)
+	t.Run("separate regexp", suite.runSeparateRegexpQueryTests)
+	t.Run("separate tag", suite.runSeparateTagQueryTests)
+	t.Run("nested list", suite.runNestedListQueryTests)
+	t.Run("tuple", suite.runTupleQueryTests)
+	t.Run("composite key", suite.runCompositeKeyQueryTests)
+	t.Run("trace", suite.runTraceQueryTests)
 }
 
 func runFieldSuccessTests(t *testing.T) {
@@ -203,6 +279,9 @@ func runFieldSuccessTests(t *testing.T) {
 
 		t.Run("struct", suite.runStructFieldTests)
 	})
+
+	t.Run("qry unmarshaler", suite.runQryUnmarshalerFieldTests)
+	t.Run("empty", suite.runEmptyFieldTests)
 }
 
 func runKeySuccessTests(t *testing.T) {
@@ -219,6 +298,9 @@ func runKeySuccessTests(t *testing.T) {
 
 		suite.runIndirectDefaultTests(t, "abc%20xyz", "abc xyz")
 	})
+
+	t.Run("qry unmarshaler", suite.runQryUnmarshalerKeyTests)
+	t.Run("composite key", suite.runCompositeKeyKeyTests)
 }
 
 func runValueListSuccessTests(t *testing.T) {
@@ -243,6 +325,21 @@ func runValueListSuccessTests(t *testing.T) {
 	t.Run("container", func(t *testing.T) {
 		t.Run("list", func(t *testing.T) { suite.runListTests(t, ",") })
 	})
+
+	t.Run("complex pair", suite.runComplexPairTests)
+	t.Run("convert type", suite.runConvertTypeValueListTests)
+	t.Run("time", suite.runTimeValueListTests)
+	t.Run("bool", suite.runBoolValueListTests)
+	t.Run("unmarshal opt-in", suite.runUnmarshalOptInValueListTests)
+	t.Run("qry unmarshaler", suite.runQryUnmarshalerValueListTests)
+	t.Run("hook", suite.runHookValueListTests)
+	t.Run("optional", suite.runOptionalValueListTests)
+	t.Run("empty", suite.runEmptyValueListTests)
+	t.Run("separate escaped", suite.runSeparateEscapedValueListTests)
+	t.Run("separate string", suite.runSeparateStringValueListTests)
+	t.Run("separate regexp", suite.runSeparateRegexpValueListTests)
+	t.Run("nested list", suite.runNestedListValueListTests)
+	t.Run("tuple", suite.runTupleValueListTests)
 }
 
 func runValueSuccessTests(t *testing.T) {
@@ -253,4 +350,14 @@ func runValueSuccessTests(t *testing.T) {
 		suite.runIndirectCommonTests(t)
 		suite.runIndirectDefaultTests(t, "abc%20xyz", "abc xyz")
 	})
+
+	t.Run("context", suite.runContextTests)
+	t.Run("convert type", suite.runConvertTypeValueTests)
+	t.Run("time", suite.runTimeValueTests)
+	t.Run("big", suite.runBigValueTests)
+	t.Run("byte encoding", suite.runByteEncodingValueTests)
+	t.Run("unmarshal opt-in", suite.runUnmarshalOptInValueTests)
+	t.Run("handler", suite.runHandlerValueTests)
+	t.Run("hook", suite.runHookValueTests)
+	t.Run("optional", suite.runOptionalValueTests)
 }
@@ -0,0 +1,185 @@
+package qry_test
+
+import (
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ===== Fuzz targets
+// Each decoder entry point is fuzzed against a set of representative target
+// types, using both the default decoder and a permissive one (literals at all
+// levels, key chaining) so as to reach more code paths. Failures are panics
+// (caught by the fuzz engine) and errors flagged as internal.
+
+type (
+	tFuzzEmbedded struct{ KeyE string }
+
+	tFuzzStruct struct {
+		KeyA string
+		KeyB []int
+		KeyC *float64
+		KeyD map[string]string
+		KeyE [2]bool `qry:"keyE"`
+		KeyF struct{ KeyX, KeyY string }
+		KeyG interface{}
+		KeyH qry.RawString
+		KeyI []byte
+		*tFuzzEmbedded
+	}
+)
+
+var fuzzTargets = map[string]func() interface{}{
+	"string":                    func() interface{} { return new(string) },
+	"bool":                      func() interface{} { return new(bool) },
+	"int8":                      func() interface{} { return new(int8) },
+	"uint":                      func() interface{} { return new(uint) },
+	"float32":                   func() interface{} { return new(float32) },
+	"complex128":                func() interface{} { return new(complex128) },
+	"*int":                      func() interface{} { return new(*int) },
+	"interface{}":               func() interface{} { return new(interface{}) },
+	"[]byte":                    func() interface{} { return new([]byte) },
+	"[4]rune":                   func() interface{} { return new([4]rune) },
+	"[]string":                  func() interface{} { return new([]string) },
+	"[3]*int":                   func() interface{} { return new([3]*int) },
+	"map[string][]string":       func() interface{} { return new(map[string][]string) },
+	"map[int]*string":           func() interface{} { return new(map[int]*string) },
+	"map[string]map[string]int": func() interface{} { return new(map[string]map[string]int) },
+	"struct":                    func() interface{} { return new(tFuzzStruct) },
+	"[]struct":                  func() interface{} { return new([]tFuzzStruct) },
+	"RawString":                 func() interface{} { return new(qry.RawString) },
+	"*big.Int":                  func() interface{} { return new(*big.Int) },
+	"*big.Float":                func() interface{} { return new(*big.Float) },
+	"big.Rat":                   func() interface{} { return new(big.Rat) },
+	"time.Time":                 func() interface{} { return new(time.Time) },
+}
+
+var fuzzSeeds = []string{
+	"",
+	"xyz",
+	"abc%20xyz",
+	"%zz",
+	"a=1&b=2,3",
+	"keyA=val%20A&keyB=1,2,3&keyC=4.5",
+	"keyD.keyX=val&keyF.keyX=x&keyF.keyY=y",
+	"keyE=true,false,true",
+	"&&=&==,,&",
+	"keyA.keyB.keyC.keyD=xyz",
+	"key%20A=val%20A1,val%20A2&key%20B=val%20B1",
+	"三=三,三",
+	"1=2&3=4",
+	"1+2i",
+}
+
+func newFuzzDecoders(t testing.TB) []*qry.Decoder {
+	defaultDecoder, err := qry.NewDecoder()
+	require.NoError(t, err, "default decoder creation")
+
+	permissiveDecoder, err := qry.NewDecoder(
+		qry.SetAllLevelsVia(qry.SetAllowLiteral),
+		qry.SeparateKeyChainBy('.'),
+		qry.IgnoreInvalidKeys(true),
+	)
+	require.NoError(t, err, "permissive decoder creation")
+
+	return []*qry.Decoder{defaultDecoder, permissiveDecoder}
+}
+
+func fuzzDecode(f *testing.F, decode func(*qry.Decoder, string, interface{}) error) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	decoders := newFuzzDecoders(f)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, decoder := range decoders {
+			for name, newTarget := range fuzzTargets {
+				err := decode(decoder, input, newTarget())
+				if err != nil && strings.Contains(err.Error(), "internal: ") {
+					t.Errorf("%s target: internal error: %s", name, err)
+				}
+			}
+		}
+	})
+}
+
+func FuzzDecodeQuery(f *testing.F) {
+	fuzzDecode(f, func(d *qry.Decoder, input string, v interface{}) error { return d.DecodeQuery(input, v) })
+}
+
+func FuzzDecodeField(f *testing.F) {
+	fuzzDecode(f, func(d *qry.Decoder, input string, v interface{}) error { return d.DecodeField(input, v) })
+}
+
+func FuzzDecodeKey(f *testing.F) {
+	fuzzDecode(f, func(d *qry.Decoder, input string, v interface{}) error { return d.DecodeKey(input, v) })
+}
+
+func FuzzDecodeValueList(f *testing.F) {
+	fuzzDecode(f, func(d *qry.Decoder, input string, v interface{}) error { return d.DecodeValueList(input, v) })
+}
+
+func FuzzDecodeValue(f *testing.F) {
+	fuzzDecode(f, func(d *qry.Decoder, input string, v interface{}) error { return d.DecodeValue(input, v) })
+}
+
+// ===== Differential fuzz target
+// Decoding into map[string][]string with default separators should agree with
+// url.ParseQuery, modulo the following documented differences:
+//
+// 1. Values are additionally split on ',' (before unescaping). Inputs
+//    containing an escaped comma are skipped, as url.ParseQuery gives no way
+//    to recover where the raw comma boundaries were.
+// 2. Empty values are dropped, so "a" and "a=" and "a=," all produce a
+//    present key with zero values rather than [""].
+// 3. Inputs url.ParseQuery rejects (bad escapes, semicolons) are skipped.
+
+func FuzzDecodeQueryParseQuery(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	decoder, err := qry.NewDecoder()
+	require.NoError(f, err, "decoder creation")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		if lower := strings.ToLower(input); strings.Contains(lower, "%2c") {
+			t.Skip("escaped comma")
+		}
+
+		parsed, err := url.ParseQuery(input)
+		if err != nil {
+			t.Skip("rejected by url.ParseQuery")
+		}
+
+		expected := make(map[string][]string, len(parsed))
+		for key, vals := range parsed {
+			items := []string{}
+			for _, val := range vals {
+				for _, item := range strings.Split(val, ",") {
+					if item != "" {
+						items = append(items, item)
+					}
+				}
+			}
+			expected[key] = items
+		}
+
+		var actual map[string][]string
+		require.NoError(t, decoder.DecodeQuery(input, &actual))
+
+		if len(expected) == 0 {
+			assert.Empty(t, actual)
+			return
+		}
+
+		assert.Equal(t, expected, actual)
+	})
+}
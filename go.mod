@@ -1,6 +1,6 @@
 module github.com/oligarch316/qry
 
-go 1.13
+go 1.18
 
 require (
 	github.com/disiqueira/gotree v1.0.0
@@ -8,3 +8,18 @@ require (
 	github.com/stretchr/testify v1.4.0
 	go.uber.org/zap v1.13.0
 )
+
+require (
+	github.com/BurntSushi/toml v0.3.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/konsorten/go-windows-terminal-sequences v1.0.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.uber.org/atomic v1.5.0 // indirect
+	go.uber.org/multierr v1.3.0 // indirect
+	go.uber.org/tools v0.0.0-20190618225709-2cfd321de3ee // indirect
+	golang.org/x/lint v0.0.0-20190930215403-16217165b5de // indirect
+	golang.org/x/sys v0.0.0-20190422165155-953cdadca894 // indirect
+	golang.org/x/tools v0.0.0-20191029190741-b9c20aec41a5 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
+	honnef.co/go/tools v0.0.1-2019.2.3 // indirect
+)
@@ -0,0 +1,67 @@
+package qry
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Handler TODO
+// Handlers are tried in order by each decode step until one reports the
+// target as handled, failing with "unsupported target type" if none do
+type Handler interface {
+	Handle(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error)
+}
+
+// HandlerFunc TODO
+type HandlerFunc func(DecodeLevel, string, reflect.Value, *DecodeState) (bool, error)
+
+// Handle TODO
+func (hf HandlerFunc) Handle(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+	return hf(level, raw, val, state)
+}
+
+// Built-in handlers, in default order
+var (
+	handlerTypes Handler = HandlerFunc(func(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+		return state.decoder.converter.handleType(level, raw, val, state)
+	})
+
+	handlerIndirects Handler = HandlerFunc(func(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+		return state.decoder.handleIndirects(level, raw, val, state)
+	})
+
+	handlerLiterals Handler = HandlerFunc(func(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+		return state.decoder.handleLiterals(level, raw, val, state)
+	})
+
+	handlerContainers Handler = HandlerFunc(func(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+		return state.decoder.handleContainers(level, raw, val, state)
+	})
+)
+
+// HandlerTypes TODO
+// Registered type converters take priority over all else, including
+// indirection (so pointer types may be registered)
+func HandlerTypes() Handler { return handlerTypes }
+
+// HandlerIndirects TODO
+func HandlerIndirects() Handler { return handlerIndirects }
+
+// HandlerLiterals TODO
+func HandlerLiterals() Handler { return handlerLiterals }
+
+// HandlerContainers TODO
+func HandlerContainers() Handler { return handlerContainers }
+
+func defaultHandlers() []Handler {
+	return []Handler{handlerTypes, handlerIndirects, handlerLiterals, handlerContainers}
+}
+
+func validateHandlers(handlers []Handler) error {
+	for _, handler := range handlers {
+		if handler == nil {
+			return errors.New("nil handler")
+		}
+	}
+	return nil
+}
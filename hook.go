@@ -0,0 +1,131 @@
+package qry
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// DecodeHook TODO
+// Run prior to the handler chain at every decode step. A hook may:
+//   - Rewrite the input by modifying the returned info's Input
+//   - Substitute the target by setting the returned info's Target to a settable
+//     value, which is decoded into via the handler chain (without re-running
+//     hooks at this step) and then assigned to the original target
+//   - Short-circuit by returning a valid value, which is assigned to the target
+//     without decoding (skipping all subsequent hooks)
+//
+// Substituted targets and short-circuit values must be assignable to the
+// original target.
+//
+// Level and KeyChain modifications are ignored.
+type DecodeHook func(DecodeInfo) (DecodeInfo, reflect.Value, error)
+
+// PostDecodeHook TODO
+// Run after each successful decode step, with the (possibly rewritten) input
+// and the final target value
+type PostDecodeHook func(DecodeInfo) error
+
+// ConfigHook TODO
+type ConfigHook struct {
+	Decode     []DecodeHook
+	PostDecode []PostDecodeHook
+}
+
+func (ch ConfigHook) validate() error {
+	for _, hook := range ch.Decode {
+		if hook == nil {
+			return errors.New("nil decode hook")
+		}
+	}
+
+	for _, hook := range ch.PostDecode {
+		if hook == nil {
+			return errors.New("nil post decode hook")
+		}
+	}
+
+	return nil
+}
+
+func (ch ConfigHook) empty() bool { return len(ch.Decode) < 1 && len(ch.PostDecode) < 1 }
+
+func (d *Decoder) handleHooked(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) error {
+	var (
+		info        = level.newInfo(raw, val)
+		substituted bool
+	)
+
+	for _, hook := range d.hooks.Decode {
+		res, short, err := hook(info)
+		if err != nil {
+			return level.wrapError(err, info.Input, val)
+		}
+
+		if short.IsValid() {
+			if err := assignHookValue(val, short); err != nil {
+				return level.wrapError(err, info.Input, val)
+			}
+			return d.postDecodeHooks(level, info.Input, val)
+		}
+
+		if !res.Target.IsValid() || !res.Target.CanSet() {
+			return level.newError("decode hook target not settable", res.Input, val)
+		}
+
+		if !sameTarget(res.Target, info.Target) {
+			substituted = true
+		}
+
+		info.Input, info.Target = res.Input, res.Target
+	}
+
+	if !substituted {
+		if err := d.handle(level, info.Input, val, state); err != nil {
+			return err
+		}
+	} else {
+		// Handle rather than decode(...) the substitute, as the hooks having
+		// run for this step would otherwise fire again (and, given a hook
+		// substituting the very type it matches, indefinitely)
+		subState := state.child()
+		subState.mark(level, info.Input, info.Target)
+
+		if err := d.handle(level, info.Input, info.Target, subState); err != nil {
+			return err
+		}
+
+		if err := assignHookValue(val, info.Target); err != nil {
+			return level.wrapError(err, info.Input, val)
+		}
+	}
+
+	return d.postDecodeHooks(level, info.Input, val)
+}
+
+func (d *Decoder) postDecodeHooks(level DecodeLevel, raw string, val reflect.Value) error {
+	info := level.newInfo(raw, val)
+
+	for _, hook := range d.hooks.PostDecode {
+		if err := hook(info); err != nil {
+			return level.wrapError(err, raw, val)
+		}
+	}
+
+	return nil
+}
+
+// sameTarget compares settable (and thus addressable) values by type and
+// address, the latter alone being ambiguous for e.g. a struct's first field
+func sameTarget(a, b reflect.Value) bool {
+	return a.Type() == b.Type() && a.Addr().Pointer() == b.Addr().Pointer()
+}
+
+func assignHookValue(dst, src reflect.Value) error {
+	if srcType, dstType := src.Type(), dst.Type(); !srcType.AssignableTo(dstType) {
+		return fmt.Errorf("decode hook value type %s not assignable to %s", srcType, dstType)
+	}
+
+	dst.Set(src)
+	return nil
+}
@@ -0,0 +1,59 @@
+package qry
+
+import "reflect"
+
+// journal records how to undo each modification made to a decode target, so
+// that a failed atomic decode can restore the target to its original state.
+//
+// Saved values are shallow copies, so the decoder must never write in place to
+// memory shared by such a copy (e.g. the backing array of a big.Int), instead
+// replacing the value outright. Values newly allocated by the decoder are not
+// part of the original target and are thus not saved (see DecodeState.save).
+//
+// NOTE:
+// Only modifications made by the decoder itself are recorded. Side effects of
+// user-defined unmarshalers beyond the memory of the value being unmarshaled
+// (e.g. writes through pointers held by that value) cannot be undone.
+type journal struct{ undos []func() }
+
+func newJournal(atomic bool) *journal {
+	if !atomic {
+		// Non-atomic => nil, checked for by all journal methods
+		return nil
+	}
+	return new(journal)
+}
+
+func (j *journal) save(val reflect.Value) {
+	if j == nil {
+		return
+	}
+
+	original := reflect.New(val.Type()).Elem()
+	original.Set(val)
+
+	j.undos = append(j.undos, func() { val.Set(original) })
+}
+
+func (j *journal) saveMapIndex(m, key reflect.Value) {
+	if j == nil {
+		return
+	}
+
+	// Invalid if the map does not contain key, in which case SetMapIndex(...)
+	// below deletes rather than restores
+	original := m.MapIndex(key)
+
+	j.undos = append(j.undos, func() { m.SetMapIndex(key, original) })
+}
+
+func (j *journal) rollback() {
+	if j == nil {
+		return
+	}
+
+	for i := len(j.undos) - 1; i >= 0; i-- {
+		j.undos[i]()
+	}
+	j.undos = nil
+}
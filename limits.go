@@ -0,0 +1,88 @@
+package qry
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConfigLimits TODO
+//
+// A zero value for any limit means unlimited.
+type ConfigLimits struct {
+	InputLength   int
+	Fields        int
+	KeyChainDepth int
+	Values        int
+	MapEntries    int
+}
+
+func (cl ConfigLimits) validate() error {
+	if cl.InputLength < 0 || cl.Fields < 0 || cl.KeyChainDepth < 0 || cl.Values < 0 || cl.MapEntries < 0 {
+		return errors.New("invalid negative limit")
+	}
+	return nil
+}
+
+// LimitName TODO
+type LimitName string
+
+// Limit TODO
+const (
+	LimitInputLength   LimitName = "input length"
+	LimitFields        LimitName = "fields"
+	LimitKeyChainDepth LimitName = "key chain depth"
+	LimitValues        LimitName = "values"
+	LimitMapEntries    LimitName = "map entries"
+)
+
+// LimitError TODO
+type LimitError struct {
+	Name LimitName
+	Max  int
+}
+
+func (le LimitError) Error() string {
+	return fmt.Sprintf("%s limit exceeded (max %d)", le.Name, le.Max)
+}
+
+func checkLimit(name LimitName, max, actual int) error {
+	if max > 0 && actual > max {
+		return LimitError{Name: name, Max: max}
+	}
+	return nil
+}
+
+func (cl ConfigLimits) checkInputLength(n int) error {
+	return checkLimit(LimitInputLength, cl.InputLength, n)
+}
+
+func (cl ConfigLimits) checkFields(n int) error { return checkLimit(LimitFields, cl.Fields, n) }
+
+func (cl ConfigLimits) checkKeyChainDepth(n int) error {
+	return checkLimit(LimitKeyChainDepth, cl.KeyChainDepth, n)
+}
+
+func (cl ConfigLimits) checkValues(n int) error { return checkLimit(LimitValues, cl.Values, n) }
+
+// mapEntryCounter tracks map entries created over the course of a single
+// top level Decode(...) call, and is thus shared by all child decode states
+type mapEntryCounter struct {
+	max, count int
+}
+
+func newMapEntryCounter(max int) *mapEntryCounter {
+	if max < 1 {
+		// No limit => nil, checked for by add()
+		return nil
+	}
+	return &mapEntryCounter{max: max}
+}
+
+func (mec *mapEntryCounter) add() error {
+	if mec == nil {
+		return nil
+	}
+
+	mec.count++
+	return checkLimit(LimitMapEntries, mec.max, mec.count)
+}
@@ -0,0 +1,75 @@
+// Package optional TODO
+package optional
+
+import (
+	"reflect"
+
+	"github.com/oligarch316/qry"
+)
+
+// State TODO
+type State int
+
+// State TODO
+const (
+	// Absent TODO
+	// The zero value, as a target never decoded into was absent from the input
+	Absent State = iota
+
+	// Empty TODO
+	Empty
+
+	// Present TODO
+	Present
+)
+
+var stateNames = map[State]string{
+	Absent:  "absent",
+	Empty:   "empty",
+	Present: "present",
+}
+
+func (s State) String() string {
+	if name, ok := stateNames[s]; ok {
+		return name
+	}
+	return "invalid"
+}
+
+// Optional TODO
+type Optional[T any] struct {
+	Value T
+	State State
+}
+
+// Get TODO
+func (o Optional[T]) Get() (T, bool) { return o.Value, o.State == Present }
+
+// IsAbsent TODO
+func (o Optional[T]) IsAbsent() bool { return o.State == Absent }
+
+// IsEmpty TODO
+func (o Optional[T]) IsEmpty() bool { return o.State == Empty }
+
+// IsPresent TODO
+func (o Optional[T]) IsPresent() bool { return o.State == Present }
+
+// UnmarshalQry TODO
+// An empty input marks the optional as empty (with a zero Value), anything
+// else is decoded into Value at the same level per the current decode state,
+// thereby honoring any field tags, set options, limits, etc.
+func (o *Optional[T]) UnmarshalQry(level qry.DecodeLevel, raw string, state *qry.DecodeState) error {
+	if raw == "" {
+		var zero T
+		o.Value, o.State = zero, Empty
+		return nil
+	}
+
+	var value T
+	if err := state.Decode(level, raw, reflect.ValueOf(&value).Elem()); err != nil {
+		return err
+	}
+
+	o.Value, o.State = value, Present
+	return nil
+}
@@ -1,11 +1,50 @@
 package qry
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
 
 // ConfigSeparate TODO
 type ConfigSeparate struct {
 	Fields, Values, KeyChain func(string) []string
 	KeyVals                  func(string) (string, string)
+
+	// NestedValues TODO
+	// Separators for value lists of value lists (e.g. [][]int), outermost
+	// first, with Values separating the innermost lists
+	NestedValues []func(string) []string
+
+	// KeyComponents TODO
+	// Separator for composite (array or struct) keys, e.g. "en:us" into a
+	// [2]string key. Composite keys are unsupported when nil
+	KeyComponents func(string) []string
+
+	// errs records an invalid separator option per field, cleared once that
+	// field is reassigned by a subsequent separator option
+	errs separatorErrors
+}
+
+type separatorErrors struct{ fields, keyVals, keyChain, keyComponents, values error }
+
+func (cs ConfigSeparate) validate() error {
+	for _, err := range []error{
+		cs.errs.fields,
+		cs.errs.keyVals,
+		cs.errs.keyChain,
+		cs.errs.keyComponents,
+		cs.errs.values,
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func separateNoopSplit(s string) []string        { return []string{s} }
@@ -30,9 +69,219 @@ func (ss separatorSet) Split(s string) []string {
 	return strings.FieldsFunc(s, ss.check)
 }
 
+func (ss separatorSet) SplitKeepEmpty(s string) []string {
+	if s == "" {
+		// Empty input => no values, consistent with Split
+		return nil
+	}
+
+	var (
+		res   []string
+		start int
+	)
+
+	for i, r := range s {
+		if ss.check(r) {
+			res = append(res, s[start:i])
+			start = i + utf8.RuneLen(r)
+		}
+	}
+
+	return append(res, s[start:])
+}
+
 func (ss separatorSet) Pair(s string) (string, string) {
 	if idx := strings.IndexFunc(s, ss.check); idx >= 0 {
 		return s[:idx], s[idx+1:]
 	}
 	return s, ""
 }
+
+// stringSeparators splits on any of multiple (multi-character) separator
+// strings, preferring the longest where several match at the same position
+type stringSeparators []string
+
+func newStringSeparators(seps ...string) stringSeparators {
+	var res stringSeparators
+	for _, sep := range seps {
+		// Empty separators would match everywhere, ignore them
+		if sep != "" {
+			res = append(res, sep)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return len(res[i]) > len(res[j]) })
+	return res
+}
+
+// index returns the start and end of the first separator in s, or -1, -1
+func (ss stringSeparators) index(s string) (int, int) {
+	for i := 0; i < len(s); i++ {
+		for _, sep := range ss {
+			if strings.HasPrefix(s[i:], sep) {
+				return i, i + len(sep)
+			}
+		}
+	}
+	return -1, -1
+}
+
+func (ss stringSeparators) Split(s string) []string {
+	var res []string
+
+	for {
+		start, end := ss.index(s)
+		if start < 0 {
+			break
+		}
+
+		// Drop empty items, consistent with separatorSet
+		if start > 0 {
+			res = append(res, s[:start])
+		}
+		s = s[end:]
+	}
+
+	if s != "" {
+		res = append(res, s)
+	}
+	return res
+}
+
+func (ss stringSeparators) Pair(s string) (string, string) {
+	if start, end := ss.index(s); start >= 0 {
+		return s[:start], s[end:]
+	}
+	return s, ""
+}
+
+// regexpSeparator splits on matches of a regular expression
+type regexpSeparator struct{ *regexp.Regexp }
+
+// validateSeparatorRegexp rejects a nil re or one that may match the empty
+// string (e.g. ",*" or "\b"), as an empty match separates nothing
+func validateSeparatorRegexp(re *regexp.Regexp) error {
+	if re == nil {
+		return errors.New("nil separator regexp")
+	}
+
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		// Compiled, but not per Perl syntax (e.g. via CompilePOSIX)
+		if !re.MatchString("") {
+			return nil
+		}
+	} else if !matchesEmpty(parsed) {
+		return nil
+	}
+
+	return fmt.Errorf("separator regexp '%s' matches empty string", re)
+}
+
+// matchesEmpty reports whether re may match the empty string, assuming any
+// empty-width assertions (e.g. ^ or \b) are satisfied
+func matchesEmpty(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpStar, syntax.OpQuest,
+		syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	case syntax.OpRepeat:
+		return re.Min == 0 || matchesEmpty(re.Sub[0])
+	case syntax.OpPlus, syntax.OpCapture:
+		return matchesEmpty(re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !matchesEmpty(sub) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if matchesEmpty(sub) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (rs regexpSeparator) Split(s string) []string {
+	var (
+		res  []string
+		prev int
+	)
+
+	for _, loc := range rs.FindAllStringIndex(s, -1) {
+		// Drop empty items, consistent with separatorSet
+		if loc[0] > prev {
+			res = append(res, s[prev:loc[0]])
+		}
+		prev = loc[1]
+	}
+
+	if prev < len(s) {
+		res = append(res, s[prev:])
+	}
+	return res
+}
+
+func (rs regexpSeparator) Pair(s string) (string, string) {
+	if loc := rs.FindStringIndex(s); loc != nil {
+		return s[:loc[0]], s[loc[1]:]
+	}
+	return s, ""
+}
+
+// escapeSplitter splits on separator runes, save those escaped by a backslash
+// (if escapes is set) or enclosed in double quotes (if quotes is set). Only a
+// literal backslash or quote is syntax, their percent-encoded forms ("%5C" and
+// "%22") being user text, as are percent-encoded separators. Escaping
+// backslashes and enclosing quotes are removed from the results.
+type escapeSplitter struct {
+	seps            separatorSet
+	escapes, quotes bool
+}
+
+func newEscapeSplitter(seps []rune, escapes, quotes bool) escapeSplitter {
+	return escapeSplitter{seps: newSeparatorSet(seps...), escapes: escapes, quotes: quotes}
+}
+
+func (es escapeSplitter) Split(s string) []string {
+	var (
+		res            []string
+		item           strings.Builder
+		escaped        bool
+		quoted, hadQts bool
+	)
+
+	flush := func() {
+		// Drop empty items as Split does, unless explicitly quoted (`""`)
+		if item.Len() > 0 || hadQts {
+			res = append(res, item.String())
+		}
+		item.Reset()
+		hadQts = false
+	}
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			item.WriteRune(r)
+			escaped = false
+		case es.escapes && r == '\\':
+			escaped = true
+		case es.quotes && r == '"':
+			quoted, hadQts = !quoted, true
+		case !quoted && es.seps.check(r):
+			flush()
+		default:
+			item.WriteRune(r)
+		}
+	}
+
+	// An unterminated quote runs to the end of input
+	flush()
+	return res
+}
@@ -1,6 +1,10 @@
 package qry
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
 
 // SetOption TODO
 type SetOption string
@@ -73,21 +77,178 @@ func (lm levelModes) with(optsMap SetOptionsMap) levelModes {
 	return res
 }
 
-type decodeState struct {
-	modes levelModes
-	trace Trace
+// DecodeState TODO
+type DecodeState struct {
+	convert    convertOptions
+	ctx        context.Context
+	decoder    *Decoder
+	journal    *journal
+	mapEntries *mapEntryCounter
+	modes      levelModes
+	trace      Trace
+
+	// Per-field separator overrides, nil => decoder-wide separators
+	chainSep, valueSep func(string) []string
+
+	// Key chain keys consumed thus far, counting toward the depth limit
+	chainDepth int
+
+	// Decoding into memory newly allocated by the decoder => no journaling
+	fresh bool
+}
+
+// Context TODO
+func (ds *DecodeState) Context() context.Context { return ds.ctx }
+
+// Decoder TODO
+func (ds *DecodeState) Decoder() *Decoder { return ds.decoder }
+
+// Decode TODO
+// Decodes raw into val as a child of the current decode, e.g. for the elements
+// of a container
+func (ds *DecodeState) Decode(level DecodeLevel, raw string, val reflect.Value) error {
+	return ds.decoder.decode(level, raw, val, ds.child())
+}
+
+// Split TODO
+// As the decoder's Split, but honoring any per-field separator overrides
+func (ds *DecodeState) Split(level DecodeLevel, raw string) ([]string, error) {
+	if level != LevelValueList || ds.valueSep == nil {
+		return ds.decoder.Split(level, raw)
+	}
+
+	res := ds.valueSep(raw)
+	if err := ds.decoder.limits.checkValues(len(res)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ds *DecodeState) checkContext() error { return ds.ctx.Err() }
+
+// withFresh returns the state marked as decoding into newly allocated memory if
+// fresh, modifications to which need not be journaled
+func (ds *DecodeState) withFresh(fresh bool) *DecodeState {
+	if !fresh || ds.fresh {
+		return ds
+	}
+
+	res := *ds
+	res.fresh = true
+	return &res
+}
+
+func (ds *DecodeState) save(val reflect.Value) {
+	if !ds.fresh {
+		ds.journal.save(val)
+	}
+}
+
+func (ds *DecodeState) saveMapIndex(m, key reflect.Value) {
+	if !ds.fresh {
+		ds.journal.saveMapIndex(m, key)
+	}
+}
+
+func (ds *DecodeState) skipEmpty(raw string) bool { return raw == "" && ds.convert.empty == EmptySkip }
+
+// boolFlag reports whether an empty input sets a target of type t as a bool
+// flag, thus exempting it from the empty mode
+func (ds *DecodeState) boolFlag(t reflect.Type) bool {
+	if !ds.convert.boolFlag {
+		return false
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Bool
+}
+
+func (ds *DecodeState) mark(level DecodeLevel, raw string, val reflect.Value) {
+	if ds.trace != nil {
+		ds.trace.Mark(level, raw, val)
+	}
+}
+
+func (ds *DecodeState) markKeyChain(rawChain []string, raw string, val reflect.Value) {
+	if ds.trace != nil {
+		markKeyChain(ds.trace, rawChain, raw, val)
+	}
+}
+
+// Unescape TODO
+// Unescapes raw and applies any transforms, marking each transform's output in
+// a child trace
+func (ds *DecodeState) Unescape(raw string, val reflect.Value) (string, error) {
+	str, err := ds.convert.unescape(raw)
+	if err != nil {
+		return "", err
+	}
+
+	for _, transform := range ds.convert.transforms {
+		str = transform(str)
+
+		if ds.trace != nil {
+			ds.trace.Child().Mark(LevelTransform, str, val)
+		}
+	}
+
+	return str, nil
+}
+
+func (ds *DecodeState) childTrace() Trace {
+	if ds.trace == nil {
+		return nil
+	}
+	return ds.trace.Child()
 }
 
-func (ds *decodeState) child() *decodeState {
-	return &decodeState{
-		modes: ds.modes,
-		trace: ds.trace.Child(),
+func (ds *DecodeState) child() *DecodeState {
+	return &DecodeState{
+		convert:    ds.convert,
+		ctx:        ds.ctx,
+		decoder:    ds.decoder,
+		journal:    ds.journal,
+		mapEntries: ds.mapEntries,
+		modes:      ds.modes,
+		trace:      ds.childTrace(),
+		chainSep:   ds.chainSep,
+		valueSep:   ds.valueSep,
+		chainDepth: ds.chainDepth,
+		fresh:      ds.fresh,
 	}
 }
 
-func (ds *decodeState) childWithSetOpts(optsMap SetOptionsMap) *decodeState {
-	return &decodeState{
-		modes: ds.modes.with(optsMap),
-		trace: ds.trace.Child(),
+// childElem returns a child state for an element of a split value list, to
+// which the value separator override (if any) of the list no longer applies
+func (ds *DecodeState) childElem() *DecodeState {
+	res := ds.child()
+	res.valueSep = nil
+	return res
+}
+
+func (ds *DecodeState) childWithItem(item structItem, defaultLevel DecodeLevel) *DecodeState {
+	res := &DecodeState{
+		convert:    ds.convert.withTag(item.baseTagInfo),
+		ctx:        ds.ctx,
+		decoder:    ds.decoder,
+		journal:    ds.journal,
+		mapEntries: ds.mapEntries,
+		modes:      ds.modes.with(item.SetOptions(defaultLevel)),
+		trace:      ds.childTrace(),
+		chainDepth: ds.chainDepth,
+		fresh:      ds.fresh,
+	}
+
+	// Separator overrides apply to the tagged field alone, not its own fields
+	if item.TagChainSeparator != "" {
+		res.chainSep = newStringSeparators(item.TagChainSeparator).Split
 	}
+
+	if item.TagSeparator != "" {
+		res.valueSep = newStringSeparators(item.TagSeparator).Split
+	}
+
+	return res
 }
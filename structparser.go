@@ -3,7 +3,10 @@ package qry
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -14,7 +17,19 @@ const (
 	sTagOmit       = "-"
 	sTagOmitEscape = sTagOmit + sTagSep
 
-	sTagBaseEmbed = "embed"
+	sTagBaseEmbed        = "embed"
+	sTagBaseFlag         = "flag"
+	sTagBaseChainSep     = "chainSep"
+	sTagBaseDirectiveSep = "="
+	sTagBaseEmpty        = "empty"
+	sTagBaseEncoding     = "encoding"
+	sTagBaseIntegerBase  = "base"
+	sTagBaseLayout       = "layout"
+	sTagBaseOptional     = "optional"
+	sTagBasePosition     = "pos"
+	sTagBaseSep          = "sep"
+	sTagBaseTransform    = "transform"
+	sTagBaseUnescape     = "unescape"
 
 	sTagSetSep = "="
 )
@@ -88,6 +103,17 @@ func (fi fieldInfo) String() string {
 type baseTagInfo struct {
 	TagName           string
 	TagEmbed, TagOmit bool
+	TagFlag           bool
+	TagOptional       bool
+	TagPosition       *int
+	TagByteEncoding   string
+	TagEmpty          EmptyMode
+	TagIntegerBase    *int
+	TagLayouts        []string
+	TagChainSeparator string
+	TagSeparator      string
+	TagTransforms     []string
+	TagUnescape       string
 }
 
 func (bti *baseTagInfo) parse(raw string) error {
@@ -108,18 +134,115 @@ func (bti *baseTagInfo) parse(raw string) error {
 	items := strings.Split(raw, sTagSep)
 	bti.TagName, items = items[0], items[1:]
 
+	var directives []string
+
 	for _, item := range items {
-		if item == sTagBaseEmbed {
+		switch item {
+		case sTagBaseEmbed:
 			bti.TagEmbed = true
 			continue
+		case sTagBaseFlag:
+			bti.TagFlag = true
+			directives = append(directives, item)
+			continue
+		case sTagBaseOptional:
+			bti.TagOptional = true
+			directives = append(directives, item)
+			continue
+		case TransformCollapse, TransformLower, TransformTrim, TransformUpper:
+			bti.TagTransforms = append(bti.TagTransforms, item)
+			directives = append(directives, item)
+			continue
+		}
+
+		split := strings.SplitN(item, sTagBaseDirectiveSep, 2)
+		if len(split) != 2 {
+			return fmt.Errorf("invalid base tag directive '%s'", item)
+		}
+
+		name := split[0]
+
+		// Directive values are percent-decoded, allowing for otherwise
+		// reserved characters such as ',' (%2C)
+		value, err := url.PathUnescape(split[1])
+		if err != nil {
+			return fmt.Errorf("invalid base tag directive '%s' value: %w", name, err)
 		}
 
-		return fmt.Errorf("invalid base tag directive '%s'", item)
+		if err = bti.parseDirective(name, value); err != nil {
+			return err
+		}
+
+		directives = append(directives, name)
 	}
 
 	// Ensure no incompatible tag directives
-	if bti.TagEmbed && bti.TagName != "" {
-		return errors.New("mutually exclusive base tag directive 'embed' and non-empty name")
+	if bti.TagEmbed {
+		if bti.TagName != "" {
+			return errors.New("mutually exclusive base tag directive 'embed' and non-empty name")
+		}
+
+		if len(directives) > 0 {
+			return fmt.Errorf("mutually exclusive base tag directives 'embed' and '%s'", directives[0])
+		}
+	}
+
+	return nil
+}
+
+func (bti *baseTagInfo) parseDirective(name, value string) error {
+	if value == "" {
+		return fmt.Errorf("empty base tag directive '%s' value", name)
+	}
+
+	switch name {
+	case sTagBaseChainSep:
+		bti.TagChainSeparator = value
+	case sTagBaseEmpty:
+		if mode := EmptyMode(value); mode.valid() {
+			bti.TagEmpty = mode
+		} else {
+			return fmt.Errorf("invalid base tag directive '%s' value '%s'", name, value)
+		}
+	case sTagBaseEncoding:
+		if _, ok := byteEncodings[value]; !ok {
+			return fmt.Errorf("invalid base tag directive '%s' value '%s'", name, value)
+		}
+
+		bti.TagByteEncoding = value
+	case sTagBaseIntegerBase:
+		base, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid base tag directive '%s' value '%s'", name, value)
+		}
+
+		if err = validateIntegerBase(base); err != nil {
+			return err
+		}
+
+		bti.TagIntegerBase = &base
+	case sTagBaseLayout:
+		bti.TagLayouts = append(bti.TagLayouts, value)
+	case sTagBasePosition:
+		pos, err := strconv.Atoi(value)
+		if err != nil || pos < 0 {
+			return fmt.Errorf("invalid base tag directive '%s' value '%s'", name, value)
+		}
+
+		bti.TagPosition = &pos
+	case sTagBaseSep:
+		bti.TagSeparator = value
+	case sTagBaseTransform:
+		// Validated against registered transforms by the struct parser
+		bti.TagTransforms = append(bti.TagTransforms, value)
+	case sTagBaseUnescape:
+		if _, ok := unescapeNames[value]; !ok {
+			return fmt.Errorf("invalid base tag directive '%s' value '%s'", name, value)
+		}
+
+		bti.TagUnescape = value
+	default:
+		return fmt.Errorf("invalid base tag directive '%s'", name)
 	}
 
 	return nil
@@ -198,18 +321,28 @@ func (sti *setTagInfo) parseExplicitOpt(rawLevel, rawOpt string) error {
 type ConfigStructParse struct{ BaseTagName, SetTagName string }
 
 type structItem struct {
-	val reflect.Value
+	name string
+	val  reflect.Value
+	baseTagInfo
 	setTagInfo
+
+	// Field index sequence from the root struct, through any embedded structs
+	index []int
 }
 
+// optional reports whether a positional value may be omitted for the item
+func (si structItem) optional() bool { return si.TagOptional || si.val.Kind() == reflect.Ptr }
+
 type structParser struct {
 	ConfigStructParse
+	checkTransform   func(string) bool
 	checkUnmarshaler func(reflect.Type) bool
 }
 
-func newStructParser(cfg ConfigStructParse, checkUnmarshaler func(reflect.Type) bool) *structParser {
+func newStructParser(cfg ConfigStructParse, checkUnmarshaler func(reflect.Type) bool, checkTransform func(string) bool) *structParser {
 	return &structParser{
 		ConfigStructParse: cfg,
+		checkTransform:    checkTransform,
 		checkUnmarshaler:  checkUnmarshaler,
 	}
 }
@@ -233,6 +366,12 @@ func (sp structParser) parseField(field reflect.StructField) (*StructFieldInfo,
 		if err := res.baseTagInfo.parse(rawBaseTag); err != nil {
 			return nil, res.wrapError(err)
 		}
+
+		for _, name := range res.TagTransforms {
+			if !sp.checkTransform(name) {
+				return nil, res.wrapError(fmt.Errorf("unknown transform '%s'", name))
+			}
+		}
 	}
 
 	if rawSetTag, setTagExists = field.Tag.Lookup(sp.SetTagName); setTagExists {
@@ -267,23 +406,100 @@ func (sp structParser) canUnmarshal(sfi *StructFieldInfo) bool {
 	return !sfi.Exported && (sp.checkUnmarshaler(sfi.Type) || sp.checkUnmarshaler(reflect.PtrTo(sfi.Type)))
 }
 
-func (sp structParser) parse(val reflect.Value) (map[string]structItem, error) {
+// parse allocates nil embedded struct pointers as it goes, calling save with
+// each such pointer prior to allocation
+func (sp structParser) parse(val reflect.Value, save func(reflect.Value)) (map[string]structItem, error) {
+	ordered, err := sp.parseOrdered(val, save)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]structItem, len(ordered))
+	for _, item := range ordered {
+		res[item.name] = item
+	}
+	return res, nil
+}
+
+// parseTuple returns the items of val in positional order, per explicit
+// 'pos' directives if present (on all items) and declaration order otherwise,
+// with the fields of embedded structs in place of the embedded field
+func (sp structParser) parseTuple(val reflect.Value, save func(reflect.Value)) ([]structItem, error) {
+	ordered, err := sp.parseOrdered(val, save)
+	if err != nil {
+		return nil, err
+	}
+
+	var positioned, unpositioned []structItem
+	for _, item := range ordered {
+		if item.TagPosition != nil {
+			positioned = append(positioned, item)
+		} else {
+			unpositioned = append(unpositioned, item)
+		}
+	}
+
+	if len(positioned) < 1 {
+		sort.SliceStable(ordered, func(i, j int) bool { return indexLess(ordered[i].index, ordered[j].index) })
+		return ordered, nil
+	}
+
+	if len(unpositioned) > 0 {
+		return nil, fmt.Errorf("unpositioned struct field '%s' among positioned fields", unpositioned[0].name)
+	}
+
+	sort.SliceStable(positioned, func(i, j int) bool { return *positioned[i].TagPosition < *positioned[j].TagPosition })
+
+	for i, item := range positioned {
+		if pos := *item.TagPosition; pos != i {
+			if pos < i {
+				return nil, fmt.Errorf("duplicate struct field position %d", pos)
+			}
+			return nil, fmt.Errorf("missing struct field position %d", i)
+		}
+	}
+
+	return positioned, nil
+}
+
+// indexLess orders field index sequences lexicographically, i.e. by declaration
+func indexLess(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// parseOrdered returns items in parse order, omitting those whose decode name
+// collides with an earlier item
+func (sp structParser) parseOrdered(val reflect.Value, save func(reflect.Value)) ([]structItem, error) {
+	type structWork struct {
+		val   reflect.Value
+		index []int
+	}
+
 	var (
-		workList = []reflect.Value{val}
-		res      = make(map[string]structItem)
+		workList = []structWork{{val: val}}
+		names    = make(map[string]bool)
+		res      []structItem
 	)
 
 	for len(workList) > 0 {
 		// Pop next item (heuristic: guarenteed kind of reflect.Struct)
-		workItem := workList[0]
+		work := workList[0]
 		workList = workList[1:]
 
 		var (
-			sType   = workItem.Type()
-			nFields = sType.NumField()
+			workItem = work.val
+			sType    = workItem.Type()
+			nFields  = sType.NumField()
 		)
 
 		for i := 0; i < nFields; i++ {
+			index := append(append(make([]int, 0, len(work.index)+1), work.index...), i)
+
 			fieldInfo, fieldErr := sp.parseField(sType.Field(i))
 			switch {
 			case fieldErr != nil:
@@ -311,7 +527,7 @@ func (sp structParser) parse(val reflect.Value) (map[string]structItem, error) {
 				switch fieldInfo.Type.Kind() {
 				case reflect.Struct:
 					// Unexported structs are fine as we can work with their zero values directly
-					workList = append(workList, workItem.Field(i))
+					workList = append(workList, structWork{workItem.Field(i), index})
 					continue
 				case reflect.Ptr:
 					if !fieldInfo.Exported {
@@ -326,10 +542,11 @@ func (sp structParser) parse(val reflect.Value) (map[string]structItem, error) {
 
 					ptrVal := workItem.Field(i)
 					if ptrVal.IsNil() {
+						save(ptrVal)
 						ptrVal.Set(reflect.New(elemType))
 					}
 
-					workList = append(workList, ptrVal.Elem())
+					workList = append(workList, structWork{ptrVal.Elem(), index})
 					continue
 				}
 
@@ -349,7 +566,7 @@ func (sp structParser) parse(val reflect.Value) (map[string]structItem, error) {
 
 				switch fieldInfo.Type.Kind() {
 				case reflect.Struct:
-					workList = append(workList, workItem.Field(i))
+					workList = append(workList, structWork{workItem.Field(i), index})
 					continue
 				case reflect.Ptr:
 					if fieldInfo.Exported {
@@ -357,10 +574,11 @@ func (sp structParser) parse(val reflect.Value) (map[string]structItem, error) {
 						if elemType.Kind() == reflect.Struct {
 							ptrVal := workItem.Field(i)
 							if ptrVal.IsNil() {
+								save(ptrVal)
 								ptrVal.Set(reflect.New(elemType))
 							}
 
-							workList = append(workList, ptrVal.Elem())
+							workList = append(workList, structWork{ptrVal.Elem(), index})
 							continue
 						}
 					}
@@ -382,11 +600,15 @@ func (sp structParser) parse(val reflect.Value) (map[string]structItem, error) {
 			// TODO: More rigorous priority definition, see
 			// https://golang.org/src/encoding/json/encode.go#L1196
 			// for inspiration. depth > from tag > index sounds right.
-			if _, collision := res[decodeName]; !collision {
-				res[decodeName] = structItem{
-					val:        workItem.Field(i),
-					setTagInfo: fieldInfo.setTagInfo,
-				}
+			if !names[decodeName] {
+				names[decodeName] = true
+				res = append(res, structItem{
+					name:        decodeName,
+					val:         workItem.Field(i),
+					baseTagInfo: fieldInfo.baseTagInfo,
+					setTagInfo:  fieldInfo.setTagInfo,
+					index:       index,
+				})
 			}
 		}
 	}
@@ -0,0 +1,104 @@
+package qry_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	tAtomicEmbedded struct{ KeyE string }
+
+	tAtomic struct {
+		KeyA     string
+		KeyB     *string
+		KeyC     map[string][]string
+		KeyD     []int
+		KeyF     *map[string]*string
+		Embedded *tAtomicEmbedded `qry:",embed"`
+		KeyZ     int
+	}
+)
+
+func newAtomicTarget() (tAtomic, *string, *string) {
+	var (
+		originalB  = "orig B"
+		originalFX = "orig FX"
+		originalF  = map[string]*string{"keyX": &originalFX}
+	)
+
+	return tAtomic{
+		KeyA: "orig A",
+		KeyB: &originalB,
+		KeyC: map[string][]string{"keyX": {"orig CX"}},
+		KeyD: []int{1, 2},
+		KeyF: &originalF,
+	}, &originalB, &originalFX
+}
+
+const tAtomicInput = "keyA=val%20A&keyB=val%20B&keyC.keyX=val%20CX&keyC.keyY=val%20CY" +
+	"&keyD=3,4&keyF.keyX=val%20FX&keyF.keyY=val%20FY&keyE=val%20E"
+
+func (dr decodeRunner) withAtomic() decodeRunner {
+	return dr.with(qry.Atomic(true), qry.SeparateKeyChainBy('.'))
+}
+
+// ===== Error
+func (des decodeErrorSuite) runAtomicTests(t *testing.T) {
+	runner := des.withAtomic()
+
+	runner.runSubtest(t, "rollback", func(t *testing.T, decode tDecode) {
+		target, originalB, originalFX := newAtomicTarget()
+		expected, _, _ := newAtomicTarget()
+
+		actual := decode(tAtomicInput+"&keyZ=xyz", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+
+		assert.Equal(t, expected, target)
+		assert.Equal(t, "orig B", *originalB)
+		assert.Equal(t, "orig FX", *originalFX)
+		assert.Nil(t, target.Embedded)
+	})
+
+	runner.runSubtest(t, "big rollback", func(t *testing.T, decode tDecode) {
+		var (
+			originalA, _ = new(big.Int).SetString("123456789012345678901234567890", 10)
+			originalB    = big.NewFloat(1.5)
+			target       = struct {
+				KeyA *big.Int
+				KeyB big.Float
+				KeyC big.Rat
+				KeyZ int
+			}{KeyA: originalA, KeyB: *originalB, KeyC: *big.NewRat(1, 3)}
+		)
+
+		actual := decode("keyA=987654321098765432109876543210&keyB=2.25&keyC=2/3&keyZ=xyz", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+
+		require.Same(t, originalA, target.KeyA)
+		assert.Equal(t, "123456789012345678901234567890", target.KeyA.String())
+		assert.Equal(t, "1.5", target.KeyB.String())
+		assert.Equal(t, "1/3", target.KeyC.String())
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runAtomicTests(t *testing.T) {
+	dss.withAtomic().runSubtest(t, "commit", func(t *testing.T, decode tDecode) {
+		target, originalB, originalFX := newAtomicTarget()
+
+		decode(tAtomicInput+"&keyZ=33", &target)
+
+		assert.Equal(t, "val A", target.KeyA)
+		assert.Equal(t, "val B", *originalB, "check original string")
+		assert.Equal(t, map[string][]string{"keyX": {"orig CX", "val CX"}, "keyY": {"val CY"}}, target.KeyC)
+		assert.Equal(t, []int{1, 2, 3, 4}, target.KeyD)
+		assert.Equal(t, "val FX", *originalFX, "check original string")
+		assert.Equal(t, "val FY", *(*target.KeyF)["keyY"])
+		assert.Equal(t, "val E", target.Embedded.KeyE)
+		assert.Equal(t, 33, target.KeyZ)
+	})
+}
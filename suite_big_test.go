@@ -0,0 +1,126 @@
+package qry_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ===== Config error
+func (ces configErrorSuite) runBigTests(t *testing.T) {
+	ces.runSubtest(t, "invalid integer base", "invalid integer base 64", qry.ConvertIntegerBaseAs(64))
+}
+
+// ===== Error
+func (des decodeErrorSuite) runBigValueTests(t *testing.T) {
+	des.runSubtest(t, "int syntax error", func(t *testing.T, decode tDecode) {
+		var target big.Int
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "invalid big.Int syntax", actual)
+	})
+
+	des.runSubtest(t, "rat syntax error", func(t *testing.T, decode tDecode) {
+		var target big.Rat
+		actual := decode("1/0", &target)
+		assertErrorMessage(t, "invalid big.Rat syntax", actual)
+	})
+
+	des.runSubtest(t, "rat exponent error", func(t *testing.T, decode tDecode) {
+		var target big.Rat
+		actual := decode("1e100000000", &target)
+		assertErrorMessage(t, "exponent out of range", actual)
+	})
+
+	des.runSubtest(t, "float syntax error", func(t *testing.T, decode tDecode) {
+		var target big.Float
+		actual := decode("xyz", &target)
+		assert.Error(t, actual)
+	})
+
+	des.runSubtest(t, "float exponent error", func(t *testing.T, decode tDecode) {
+		var target big.Float
+		actual := decode("1e100000000", &target)
+		assertErrorMessage(t, "exponent out of range", actual)
+	})
+
+	des.runSubtest(t, "float negative exponent error", func(t *testing.T, decode tDecode) {
+		var target big.Float
+		actual := decode("-1E-99999999999999999999", &target)
+		assertErrorMessage(t, "exponent out of range", actual)
+	})
+
+	des.with(qry.ConvertIntegerBaseAs(36)).runSubtest(t, "float base error", func(t *testing.T, decode tDecode) {
+		var target big.Float
+		actual := decode("1", &target)
+		assertErrorMessage(t, "invalid big.Float base 36", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runBigQueryTests(t *testing.T) {
+	dss.runSubtest(t, "all types", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA *big.Int
+			KeyB *big.Float
+			KeyC *big.Rat
+			KeyD []*big.Int
+		}
+
+		decode("keyA=123456789012345678901234567890&keyB=1.5e3&keyC=1/3&keyD=0x10,-7", &target)
+
+		expectedA, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		assert.Equal(t, 0, expectedA.Cmp(target.KeyA), "keyA")
+		assert.Equal(t, "1500", target.KeyB.Text('f', -1), "keyB")
+		assert.Equal(t, big.NewRat(1, 3), target.KeyC, "keyC")
+		require.Len(t, target.KeyD, 2)
+		assert.Equal(t, int64(16), target.KeyD[0].Int64(), "keyD[0]")
+		assert.Equal(t, int64(-7), target.KeyD[1].Int64(), "keyD[1]")
+	})
+
+	dss.runSubtest(t, "tag integer base", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA big.Float `qry:"keyA,base=16"`
+			KeyB big.Float `qry:"keyB"`
+		}
+
+		decode("keyA=1e5&keyB=1e5", &target)
+		assert.Equal(t, "485", target.KeyA.Text('f', -1), "keyA")
+		assert.Equal(t, "100000", target.KeyB.Text('f', -1), "keyB")
+	})
+}
+
+func (dss decodeSuccessSuite) runBigValueTests(t *testing.T) {
+	runner := dss.with(qry.ConvertIntegerBaseAs(16))
+
+	runner.runSubtest(t, "int integer base", func(t *testing.T, decode tDecode) {
+		var target big.Int
+		decode("ff", &target)
+		assert.Equal(t, int64(255), target.Int64())
+	})
+
+	runner.runSubtest(t, "float integer base", func(t *testing.T, decode tDecode) {
+		var target big.Float
+		decode("ff.8p0", &target)
+		assert.Equal(t, "255.5", target.Text('f', -1))
+	})
+
+	runner.runSubtest(t, "float integer base hex digit e", func(t *testing.T, decode tDecode) {
+		var target big.Float
+		decode("1e100000000", &target)
+		assert.Equal(t, "2065879269376", target.Text('f', -1))
+	})
+
+	dss.with(
+		qry.ConvertBigFloatPrecisionAs(4),
+		qry.ConvertBigFloatModeAs(big.ToZero),
+	).runSubtest(t, "float precision and mode", func(t *testing.T, decode tDecode) {
+		var target big.Float
+		decode("31", &target)
+		assert.Equal(t, uint(4), target.Prec())
+		assert.Equal(t, big.ToZero, target.Mode())
+		assert.Equal(t, "30", target.Text('f', -1))
+	})
+}
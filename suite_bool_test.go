@@ -0,0 +1,104 @@
+package qry_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tBoolFlag struct {
+	Verbose bool  `qry:"verbose,flag"`
+	Debug   *bool `qry:"debug,flag"`
+	Strict  bool  `qry:"strict"`
+}
+
+func (dr decodeRunner) withBoolWords() decodeRunner {
+	return dr.with(qry.ConvertBoolWordsAs([]string{"yes", "on", "1"}, []string{"no", "off", "0"}))
+}
+
+// ===== Config error
+func (ces configErrorSuite) runBoolTests(t *testing.T) {
+	ces.runSubtest(t, "empty true words", "empty true bool word list", qry.ConvertBoolWordsAs(nil, []string{"no"}))
+	ces.runSubtest(t, "empty false words", "empty false bool word list", qry.ConvertBoolWordsAs([]string{"yes"}, nil))
+	ces.runSubtest(
+		t, "ambiguous word",
+		"bool word 'x' both true and false",
+		qry.ConvertBoolWordsAs([]string{"yes", "X"}, []string{"no", "x"}),
+	)
+}
+
+// ===== Error
+func (des decodeErrorSuite) runBoolQueryTests(t *testing.T) {
+	des.runSubtest(t, "valueless non-flag error", func(t *testing.T, decode tDecode) {
+		var target tBoolFlag
+		actual := decode("strict", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+}
+
+func (des decodeErrorSuite) runBoolValueListTests(t *testing.T) {
+	des.withBoolWords().runSubtest(t, "words error", func(t *testing.T, decode tDecode) {
+		var target []bool
+		actual := decode("true", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runBoolQueryTests(t *testing.T) {
+	dss.runSubtest(t, "flag", func(t *testing.T, decode tDecode) {
+		var target tBoolFlag
+
+		decode("verbose&debug=", &target)
+		assert.True(t, target.Verbose)
+		require.NotNil(t, target.Debug)
+		assert.True(t, *target.Debug)
+	})
+
+	dss.runSubtest(t, "flag explicit value", func(t *testing.T, decode tDecode) {
+		target := tBoolFlag{Verbose: true}
+
+		decode("verbose=false", &target)
+		assert.False(t, target.Verbose)
+	})
+
+	dss.with(qry.ConvertBoolFlag(true)).runSubtest(t, "flag config", func(t *testing.T, decode tDecode) {
+		var target struct{ Verbose bool }
+		decode("verbose", &target)
+		assert.True(t, target.Verbose)
+	})
+
+	dss.withKeyChainSep('.').with(
+		qry.ConvertBoolFlag(true),
+		qry.ConvertEmptyAs(qry.EmptySkip),
+	).runSubtest(t, "flag config map chain skip empty", func(t *testing.T, decode tDecode) {
+		var target map[string]map[string]bool
+		decode("opts.verbose&opts.debug=", &target)
+		assert.Equal(t, map[string]map[string]bool{"opts": {"verbose": true, "debug": true}}, target)
+	})
+
+	dss.withKeyChainSep('.').with(
+		qry.ConvertBoolFlag(true),
+		qry.ConvertEmptyAs(qry.EmptySkip),
+	).runSubtest(t, "flag config struct map skip empty", func(t *testing.T, decode tDecode) {
+		var target struct{ Opts map[string]bool }
+		decode("opts.verbose", &target)
+		assert.Equal(t, map[string]bool{"verbose": true}, target.Opts)
+	})
+}
+
+func (dss decodeSuccessSuite) runBoolValueListTests(t *testing.T) {
+	dss.withBoolWords().runSubtest(t, "words", func(t *testing.T, decode tDecode) {
+		var target []bool
+		decode("yes,OFF,On,no,1,0", &target)
+		assert.Equal(t, []bool{true, false, true, false, true, false}, target)
+	})
+
+	dss.runSubtest(t, "default words", func(t *testing.T, decode tDecode) {
+		var target []bool
+		decode("true,F,TRUE,0", &target)
+		assert.Equal(t, []bool{true, false, true, false}, target)
+	})
+}
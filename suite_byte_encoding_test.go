@@ -0,0 +1,78 @@
+package qry_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+var tByteEncodingExpected = []byte{0xDE, 0xAD, 0xBE, 0xEF, 0xFB}
+
+// ===== Config error
+func (ces configErrorSuite) runByteEncodingTests(t *testing.T) {
+	ces.runSubtest(t, "invalid encoding", "invalid byte encoding 'base32'", qry.ConvertByteEncodingAs("base32"))
+}
+
+// ===== Error
+func (des decodeErrorSuite) runByteEncodingQueryTests(t *testing.T) {
+	des.runSubtest(t, "invalid tag encoding error", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Key []byte `qry:"key,encoding=base32"`
+		}
+
+		actual := decode("key=xyz", &target)
+		assertErrorMessage(t, "invalid base tag directive 'encoding' value 'base32'", actual)
+	})
+}
+
+func (des decodeErrorSuite) runByteEncodingValueTests(t *testing.T) {
+	runner := des.with(qry.ConvertByteEncodingAs(qry.ByteEncodingHex))
+
+	runner.runSubtest(t, "array length error", func(t *testing.T, decode tDecode) {
+		var target [4]byte
+		actual := decode("deadbeeffb", &target)
+		assertErrorMessage(t, "insufficient destination array length", actual)
+	})
+
+	runner.runSubtest(t, "decode error", func(t *testing.T, decode tDecode) {
+		var target []byte
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "encoding/hex: invalid byte: U+0078 'x'", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runByteEncodingQueryTests(t *testing.T) {
+	dss.runSubtest(t, "tag", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Std    []byte   `qry:"std,encoding=base64"`
+			URL    []byte   `qry:"url,encoding=base64url"`
+			Hex    [5]byte  `qry:"hex,encoding=hex"`
+			Raw    []byte   `qry:"raw,encoding=raw"`
+			List   [][]byte `qry:"list,encoding=hex"`
+			Runes  []rune   `qry:"runes,encoding=hex"`
+			Plain  []byte   `qry:"plain"`
+			Padded []byte   `qry:"padded,encoding=base64url"`
+		}
+
+		decode("std=3q2%2B7%2Fs&url=3q2-7_s&hex=deadbeeffb&raw=xyz&list=de,adbe&runes=abc&plain=xyz&padded=3q2-7_s%3D", &target)
+		assert.Equal(t, tByteEncodingExpected, target.Std)
+		assert.Equal(t, tByteEncodingExpected, target.URL)
+		assert.Equal(t, tByteEncodingExpected, target.Hex[:])
+		assert.Equal(t, []byte("xyz"), target.Raw)
+		assert.Equal(t, [][]byte{{0xDE}, {0xAD, 0xBE}}, target.List)
+		assert.Equal(t, []rune("abc"), target.Runes, "check rune targets unaffected")
+		assert.Equal(t, []byte("xyz"), target.Plain)
+		assert.Equal(t, tByteEncodingExpected, target.Padded)
+	})
+}
+
+func (dss decodeSuccessSuite) runByteEncodingValueTests(t *testing.T) {
+	dss.with(qry.ConvertByteEncodingAs(qry.ByteEncodingHex)).runSubtest(t, "array length", func(t *testing.T, decode tDecode) {
+		// 10 characters of encoded text, 5 bytes decoded
+		var target [5]byte
+		decode("deadbeeffb", &target)
+		assert.Equal(t, tByteEncodingExpected, target[:])
+	})
+}
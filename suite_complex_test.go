@@ -0,0 +1,54 @@
+package qry_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+// ===== Error
+func (des decodeErrorSuite) runComplexPairTests(t *testing.T) {
+	runner := des.with(qry.ConvertComplexPairs(true))
+
+	runner.runSubtest(t, "single value error", func(t *testing.T, decode tDecode) {
+		var target complex128
+		actual := decode("1.5", &target)
+		assertErrorMessage(t, "complex value list not a (real, imaginary) pair", actual)
+	})
+
+	runner.runSubtest(t, "extra value error", func(t *testing.T, decode tDecode) {
+		var target complex128
+		actual := decode("1.5,2,3", &target)
+		assertErrorMessage(t, "complex value list not a (real, imaginary) pair", actual)
+	})
+
+	runner.runSubtest(t, "imaginary part error", func(t *testing.T, decode tDecode) {
+		var target complex64
+		actual := decode("1.5,2i", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runComplexPairTests(t *testing.T) {
+	runner := dss.with(qry.ConvertComplexPairs(true))
+
+	runner.runSubtest(t, "complex64 target", func(t *testing.T, decode tDecode) {
+		var target complex64
+		decode("1.5,-2", &target)
+		assert.Equal(t, complex64(complex(1.5, -2)), target)
+	})
+
+	runner.runSubtest(t, "*complex128 target", func(t *testing.T, decode tDecode) {
+		var target *complex128
+		decode("1.5,2.25", &target)
+		assert.Equal(t, complex(1.5, 2.25), *target)
+	})
+
+	runner.withSetOpts(qry.SetDisallowLiteral).runSubtest(t, "disallowed literal", func(t *testing.T, decode tDecode) {
+		var target complex128
+		decode("0,1", &target)
+		assert.Equal(t, complex(0, 1), target)
+	})
+}
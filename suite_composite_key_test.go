@@ -0,0 +1,128 @@
+package qry_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	tCompositeKeyLocale struct{ Lang, Region string }
+
+	tCompositeKeyTenant struct {
+		Tenant int                 `qry:"tenant"`
+		Locale tCompositeKeyLocale `qry:"locale"`
+		Flag   bool                `qry:"flag,optional"`
+	}
+)
+
+func (dr decodeRunner) withKeyComponentSep(r rune) decodeRunner {
+	return dr.with(qry.SeparateKeyComponentsBy(r), qry.SeparateKeyChainBy('.'))
+}
+
+// ===== Error
+func (des decodeErrorSuite) runCompositeKeyQueryTests(t *testing.T) {
+	runner := des.withKeyComponentSep(':')
+
+	runner.runSubtest(t, "array length error", func(t *testing.T, decode tDecode) {
+		var target map[[2]string]int
+		actual := decode("a:b:c=1", &target)
+		assertErrorMessage(t, "insufficient destination array length", actual)
+	})
+
+	runner.runSubtest(t, "struct too many values error", func(t *testing.T, decode tDecode) {
+		var target map[tCompositeKeyLocale]int
+		actual := decode("a:b:c=1", &target)
+		assertErrorMessage(t, "too many values for struct", actual)
+	})
+
+	runner.runSubtest(t, "struct missing value error", func(t *testing.T, decode tDecode) {
+		var target map[tCompositeKeyLocale]int
+		actual := decode("a=1", &target)
+		assertErrorMessage(t, "missing value for struct field 'region'", actual)
+	})
+
+	runner.runSubtest(t, "component error", func(t *testing.T, decode tDecode) {
+		var target map[[2]int]int
+		actual := decode("1:x=1", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+
+	des.runSubtest(t, "unsupported array error", func(t *testing.T, decode tDecode) {
+		var target map[[2]string]int
+		actual := decode("en:us=5", &target)
+		assertErrorMessage(t, "unsupported target type", actual)
+	})
+
+	des.runSubtest(t, "unsupported struct error", func(t *testing.T, decode tDecode) {
+		var target map[tCompositeKeyLocale]int
+		actual := decode("en:us=5", &target)
+		assertErrorMessage(t, "unsupported target type", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runCompositeKeyQueryTests(t *testing.T) {
+	runner := dss.withKeyComponentSep(':')
+
+	runner.runSubtest(t, "array", func(t *testing.T, decode tDecode) {
+		var target map[[2]string]int
+
+		decode("en:us=5&fr:ca=6&de=7", &target)
+		assert.Equal(t, map[[2]string]int{{"en", "us"}: 5, {"fr", "ca"}: 6, {"de", ""}: 7}, target)
+	})
+
+	runner.runSubtest(t, "struct", func(t *testing.T, decode tDecode) {
+		var target map[tCompositeKeyLocale][]string
+
+		decode("en:gb=colour&en:us=color,colors", &target)
+		assert.Equal(t, map[tCompositeKeyLocale][]string{
+			{"en", "gb"}: {"colour"},
+			{"en", "us"}: {"color", "colors"},
+		}, target)
+	})
+
+	runner.runSubtest(t, "value list component", func(t *testing.T, decode tDecode) {
+		var target map[tCompositeKeyTenant]string
+
+		decode("1:en,us=a&2:fr,ca:true=b", &target)
+		assert.Equal(t, map[tCompositeKeyTenant]string{
+			{Tenant: 1, Locale: tCompositeKeyLocale{"en", "us"}}:             "a",
+			{Tenant: 2, Locale: tCompositeKeyLocale{"fr", "ca"}, Flag: true}: "b",
+		}, target)
+	})
+
+	runner.runSubtest(t, "key chain", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Labels map[[2]string]string
+		}
+
+		decode("labels.en:us=color", &target)
+		assert.Equal(t, map[[2]string]string{{"en", "us"}: "color"}, target.Labels)
+	})
+
+	dss.with(qry.SeparateKeyComponentsByString("::")).runSubtest(t, "string", func(t *testing.T, decode tDecode) {
+		var target map[[2]string]int
+
+		decode("a:b::c=1", &target)
+		assert.Equal(t, map[[2]string]int{{"a:b", "c"}: 1}, target)
+	})
+
+	dss.with(qry.SeparateKeyComponentsByRegexp(regexp.MustCompile(`[:/]`))).runSubtest(t, "regexp", func(t *testing.T, decode tDecode) {
+		var target map[[2]string]int
+
+		decode("a:b=1&c/d=2", &target)
+		assert.Equal(t, map[[2]string]int{{"a", "b"}: 1, {"c", "d"}: 2}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runCompositeKeyKeyTests(t *testing.T) {
+	dss.withKeyComponentSep(':').runSubtest(t, "array", func(t *testing.T, decode tDecode) {
+		var target [2]string
+
+		decode("en:us", &target)
+		assert.Equal(t, [2]string{"en", "us"}, target)
+	})
+}
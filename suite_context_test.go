@@ -0,0 +1,143 @@
+package qry_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+type tContextKey struct{}
+
+type tContextUnmarshaler struct {
+	ctxVal interface{}
+	val    string
+}
+
+func (tcu *tContextUnmarshaler) UnmarshalTextContext(ctx context.Context, text []byte) error {
+	tcu.ctxVal = ctx.Value(tContextKey{})
+	tcu.val = string(text)
+	return nil
+}
+
+// tCancelUnmarshaler cancels the decode in progress via the context.CancelFunc
+// held by its context, as from within a long running unmarshaler
+type tCancelUnmarshaler string
+
+type tCancelKey struct{}
+
+func (tcu *tCancelUnmarshaler) UnmarshalTextContext(ctx context.Context, text []byte) error {
+	if cancel, ok := ctx.Value(tCancelKey{}).(context.CancelFunc); ok {
+		cancel()
+	}
+
+	*tcu = tCancelUnmarshaler(text)
+	return nil
+}
+
+// ===== Error
+func (des decodeErrorSuite) runContextTests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var (
+		input  = "keyA.keyX=val%20AX&keyB=val%20B"
+		runner = des.withContext(ctx).with(qry.SeparateKeyChainBy('.'))
+	)
+
+	runner.runSubtest(t, "canceled list", func(t *testing.T, decode tDecode) {
+		var target []string
+		actual := decode(input, &target)
+		assert.True(t, errors.Is(actual, context.Canceled), "check canceled: %v", actual)
+	})
+
+	runner.runSubtest(t, "canceled map", func(t *testing.T, decode tDecode) {
+		var target map[string]string
+		actual := decode(input, &target)
+		assert.True(t, errors.Is(actual, context.Canceled), "check canceled: %v", actual)
+	})
+
+	runner.runSubtest(t, "canceled map chain", func(t *testing.T, decode tDecode) {
+		var target map[string]map[string]string
+		actual := decode(input, &target)
+		assert.True(t, errors.Is(actual, context.Canceled), "check canceled: %v", actual)
+	})
+
+	runner.runSubtest(t, "canceled struct", func(t *testing.T, decode tDecode) {
+		var target struct{ KeyA, KeyB string }
+		actual := decode(input, &target)
+		assert.True(t, errors.Is(actual, context.Canceled), "check canceled: %v", actual)
+	})
+
+	runner.runSubtest(t, "canceled struct chain", func(t *testing.T, decode tDecode) {
+		var target struct{ KeyA struct{ KeyX string } }
+		actual := decode(input, &target)
+		assert.True(t, errors.Is(actual, context.Canceled), "check canceled: %v", actual)
+	})
+
+	type tCancelTarget struct {
+		First         tCancelUnmarshaler
+		Second, Third string
+	}
+
+	var (
+		cancelInput = "first=valA&second=valB&third=valC"
+		original    = tCancelTarget{First: "origA", Second: "origB", Third: "origC"}
+	)
+
+	cancelContext := func() context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		return context.WithValue(ctx, tCancelKey{}, cancel)
+	}
+
+	des.withContext(cancelContext()).runSubtest(t, "canceled during decode", func(t *testing.T, decode tDecode) {
+		target := original
+		actual := decode(cancelInput, &target)
+		assert.True(t, errors.Is(actual, context.Canceled), "check canceled: %v", actual)
+
+		// First field decoded before cancellation, later fields untouched
+		expected := tCancelTarget{First: "valA", Second: "origB", Third: "origC"}
+		assert.Equal(t, expected, target)
+	})
+
+	des.withContext(cancelContext()).with(qry.Atomic(true)).runSubtest(t, "canceled during atomic decode", func(t *testing.T, decode tDecode) {
+		target := original
+		actual := decode(cancelInput, &target)
+		assert.True(t, errors.Is(actual, context.Canceled), "check canceled: %v", actual)
+		assert.Equal(t, original, target)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runContextTests(t *testing.T) {
+	ctx := context.WithValue(context.Background(), tContextKey{}, "xyz")
+
+	dss.withContext(ctx).runSubtest(t, "unmarshaler", func(t *testing.T, decode tDecode) {
+		var target tContextUnmarshaler
+		decode("val%20A", &target)
+		assert.Equal(t, "xyz", target.ctxVal)
+		assert.Equal(t, "val A", target.val)
+	})
+
+	var ctxVals []interface{}
+
+	recorder := qry.ContextTraceMarker(func(ctx context.Context, _ qry.DecodeLevel, _ string, _ reflect.Value) {
+		ctxVals = append(ctxVals, ctx.Value(tContextKey{}))
+	})
+
+	dss.withContext(ctx).withTraces(recorder).runSubtest(t, "trace", func(t *testing.T, decode tDecode) {
+		var target string
+		decode("val", &target)
+		assert.Equal(t, []interface{}{"xyz"}, ctxVals)
+	})
+
+	dss.withContext(nil).runSubtest(t, "nil context", func(t *testing.T, decode tDecode) {
+		var target tContextUnmarshaler
+		decode("val%20A", &target)
+		assert.Nil(t, target.ctxVal)
+		assert.Equal(t, "val A", target.val)
+	})
+}
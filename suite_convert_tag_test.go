@@ -0,0 +1,66 @@
+package qry_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	tConvertTagBase struct {
+		Color  uint32   `qry:"color,base=16"`
+		Masks  []uint16 `qry:"masks,base=2"`
+		Big    *big.Int `qry:"big,base=16"`
+		Count  int      `qry:"count"`
+		Prefix int      `qry:"prefix,base=0"`
+	}
+
+	tConvertTagUnescape struct {
+		Path  string       `qry:"path,unescape=path"`
+		Query string       `qry:"query,unescape=query"`
+		Raw   []string     `qry:"raw,unescape=none"`
+		Bytes []byte       `qry:"bytes,unescape=path"`
+		Text  tUnmarshaler `qry:"text,unescape=none"`
+	}
+)
+
+// ===== Error
+func (des decodeErrorSuite) runConvertTagQueryTests(t *testing.T) {
+	des.runSubtest(t, "integer base error", func(t *testing.T, decode tDecode) {
+		var target tConvertTagBase
+		actual := decode("masks=12", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+
+	des.runSubtest(t, "unescape error", func(t *testing.T, decode tDecode) {
+		var target tConvertTagUnescape
+		actual := decode("path=%zz", &target)
+		assertErrorMessage(t, `invalid URL escape "%zz"`, actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runConvertTagQueryTests(t *testing.T) {
+	dss.runSubtest(t, "integer base", func(t *testing.T, decode tDecode) {
+		var target tConvertTagBase
+
+		decode("color=ff8800&masks=101,11&big=ffffffffffffffffff&count=010&prefix=0x10", &target)
+		assert.Equal(t, uint32(0xff8800), target.Color)
+		assert.Equal(t, []uint16{5, 3}, target.Masks)
+		assert.Equal(t, "ffffffffffffffffff", target.Big.Text(16))
+		assert.Equal(t, 8, target.Count, "check decoder-wide base 0 (octal prefix)")
+		assert.Equal(t, 16, target.Prefix)
+	})
+
+	dss.runSubtest(t, "unescape", func(t *testing.T, decode tDecode) {
+		var target tConvertTagUnescape
+
+		decode("path=a+b%20c&query=a+b%20c&raw=a+b,%20c&bytes=x+y&text=a%20b", &target)
+		assert.Equal(t, "a+b c", target.Path)
+		assert.Equal(t, "a b c", target.Query)
+		assert.Equal(t, []string{"a+b", "%20c"}, target.Raw)
+		assert.Equal(t, []byte("x+y"), target.Bytes)
+		assert.Equal(t, "a%20b", target.Text.val)
+	})
+}
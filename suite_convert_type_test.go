@@ -0,0 +1,101 @@
+package qry_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+type tConvertID struct{ prefix, id string }
+
+func parseConvertID(s string) (tConvertID, error) {
+	split := strings.SplitN(s, "_", 2)
+	if len(split) != 2 {
+		return tConvertID{}, errors.New("invalid id")
+	}
+	return tConvertID{prefix: split[0], id: split[1]}, nil
+}
+
+// ===== Config error
+func (ces configErrorSuite) runConvertTypeTests(t *testing.T) {
+	ces.runSubtest(
+		t, "invalid level",
+		"invalid convert level for type qry_test.tConvertID: root",
+		qry.ConvertTypeAs(parseConvertID, qry.LevelRoot),
+	)
+}
+
+// ===== Error
+func (des decodeErrorSuite) runConvertTypeValueTests(t *testing.T) {
+	des.with(qry.ConvertTypeAs(parseConvertID)).runSubtest(t, "convert error", func(t *testing.T, decode tDecode) {
+		var target tConvertID
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "invalid id", actual)
+	})
+}
+
+func (des decodeErrorSuite) runConvertTypeValueListTests(t *testing.T) {
+	runner := des.with(qry.ConvertTypeAs(parseConvertID, qry.LevelValue))
+
+	runner.runSubtest(t, "level scope error", func(t *testing.T, decode tDecode) {
+		var target tConvertID
+		actual := decode("usr_a", &target)
+		assertErrorMessage(t, "unsupported target type", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runConvertTypeQueryTests(t *testing.T) {
+	runner := dss.with(qry.ConvertTypeAs(parseConvertID))
+
+	runner.runSubtest(t, "generic", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA tConvertID
+			KeyB *tConvertID
+			KeyC []tConvertID
+		}
+
+		decode("keyA=usr_a&keyB=usr_b&keyC=usr_c1,usr_c2", &target)
+		assert.Equal(t, tConvertID{"usr", "a"}, target.KeyA)
+		assert.Equal(t, &tConvertID{"usr", "b"}, target.KeyB)
+		assert.Equal(t, []tConvertID{{"usr", "c1"}, {"usr", "c2"}}, target.KeyC)
+	})
+}
+
+func (dss decodeSuccessSuite) runConvertTypeValueListTests(t *testing.T) {
+	runner := dss.with(qry.ConvertTypeAs(parseConvertID, qry.LevelValue))
+
+	runner.runSubtest(t, "level scope", func(t *testing.T, decode tDecode) {
+		var target []tConvertID
+		decode("usr_a,usr_b", &target)
+		assert.Equal(t, []tConvertID{{"usr", "a"}, {"usr", "b"}}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runConvertTypeValueTests(t *testing.T) {
+	set := func(s string, val reflect.Value) error {
+		val.Set(reflect.ValueOf(&tConvertID{prefix: "ptr", id: s}))
+		return nil
+	}
+
+	dss.with(qry.ConvertTypeVia(reflect.TypeOf(&tConvertID{}), set)).runSubtest(t, "reflect", func(t *testing.T, decode tDecode) {
+		var target *tConvertID
+		decode("abc%20xyz", &target)
+		assert.Equal(t, &tConvertID{"ptr", "abc xyz"}, target)
+	})
+
+	parse := func(s string) (tUnmarshaler, error) {
+		return tUnmarshaler{tUnmarshalerData{val: "converted " + s}}, nil
+	}
+
+	dss.with(qry.ConvertTypeAs(parse)).runSubtest(t, "unmarshaler priority", func(t *testing.T, decode tDecode) {
+		var target tUnmarshaler
+		decode("xyz", &target)
+		assert.False(t, target.called, "check unmarshal function was not called")
+		assert.Equal(t, "converted xyz", target.val)
+	})
+}
@@ -1,6 +1,7 @@
 package qry_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -28,13 +29,16 @@ type (
 )
 
 type decodeRunner struct {
+	ctx     context.Context
 	errHook func(*testing.T, error) error
 	level   qry.DecodeLevel
 	opts    []qry.Option
+	traces  []qry.Trace
 }
 
 func newDecodeRunner(level qry.DecodeLevel, errHook func(*testing.T, error) error) decodeRunner {
 	return decodeRunner{
+		ctx:     context.Background(),
 		errHook: errHook,
 		level:   level,
 
@@ -50,7 +54,7 @@ func newDecodeRunner(level qry.DecodeLevel, errHook func(*testing.T, error) erro
 }
 
 func (dr decodeRunner) with(opts ...qry.Option) decodeRunner {
-	res := decodeRunner{errHook: dr.errHook, level: dr.level}
+	res := decodeRunner{ctx: dr.ctx, errHook: dr.errHook, level: dr.level, traces: dr.traces}
 	if len(dr.opts) > 0 {
 		res.opts = make([]qry.Option, len(dr.opts))
 		copy(res.opts, dr.opts)
@@ -59,6 +63,18 @@ func (dr decodeRunner) with(opts ...qry.Option) decodeRunner {
 	return res
 }
 
+func (dr decodeRunner) withContext(ctx context.Context) decodeRunner {
+	res := dr.with()
+	res.ctx = ctx
+	return res
+}
+
+func (dr decodeRunner) withTraces(traces ...qry.Trace) decodeRunner {
+	res := dr.with()
+	res.traces = append(append([]qry.Trace{}, dr.traces...), traces...)
+	return res
+}
+
 func (dr decodeRunner) withSetOpts(setOpts ...qry.SetOption) decodeRunner {
 	opts := make([]qry.Option, len(setOpts))
 	for i, setOpt := range setOpts {
@@ -89,7 +105,8 @@ func (dr decodeRunner) runTest(t *testing.T, test decodeTest) {
 	// Test
 	assert.NotPanics(t, func() {
 		test(t, func(input string, v interface{}) error {
-			err := decoder.Decode(dr.level, input, v, trace)
+			traces := append([]qry.Trace{trace}, dr.traces...)
+			err := decoder.DecodeContext(dr.ctx, dr.level, input, v, traces...)
 			if dr.errHook == nil {
 				return err
 			}
@@ -145,6 +162,17 @@ func assertErrorAs(t *testing.T, expected interface{}, actual error, msgAndArgs
 	return true
 }
 
+// withDecodeErrors returns a runner whose curried decode() yields the
+// DecodeError itself, for tests of its info rather than the underlying error
+func (des decodeErrorSuite) withDecodeErrors() decodeRunner {
+	res := des.with()
+	res.errHook = func(t *testing.T, err error) error {
+		require.Error(t, err)
+		return err
+	}
+	return res
+}
+
 func (des decodeErrorSuite) withUnescapeError(msg string) decodeRunner {
 	unescapeF := func(_ string) (string, error) { return "", errors.New(msg) }
 	return des.with(qry.ConvertUnescapeAs(unescapeF))
@@ -231,6 +259,18 @@ func (des decodeErrorSuite) runUnsupportedKeyValTests(t *testing.T) {
 	})
 }
 
+// ===== Config error suite
+// Covers option validation performed by NewDecoder(...), outside of any decode
+
+type configErrorSuite struct{}
+
+func (configErrorSuite) runSubtest(t *testing.T, name, expected string, opts ...qry.Option) {
+	t.Run(name, func(t *testing.T) {
+		_, err := qry.NewDecoder(opts...)
+		assert.EqualError(t, err, expected)
+	})
+}
+
 // ===== Success suite
 
 type decodeSuccessSuite struct{ decodeRunner }
@@ -0,0 +1,221 @@
+package qry_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (dr decodeRunner) withKeepEmpty() decodeRunner {
+	return dr.with(qry.SeparateValuesKeepEmptyBy(','))
+}
+
+// ===== Config error
+func (ces configErrorSuite) runEmptyTests(t *testing.T) {
+	ces.runSubtest(t, "invalid mode", "invalid empty mode 'maybe'", qry.ConvertEmptyAs("maybe"))
+}
+
+// ===== Error
+func (des decodeErrorSuite) runEmptyQueryTests(t *testing.T) {
+	des.runSubtest(t, "decode error", func(t *testing.T, decode tDecode) {
+		var target struct{ KeyA int }
+		actual := decode("keyA=", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+
+	des.with(qry.ConvertEmptyAs(qry.EmptyError)).runSubtest(t, "error mode", func(t *testing.T, decode tDecode) {
+		var target struct{ KeyA []string }
+		actual := decode("keyA", &target)
+		assertErrorMessage(t, "empty value", actual)
+	})
+
+	des.runSubtest(t, "tag error mode", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyD []int `qry:"keyD,empty=error"`
+		}
+		actual := decode("keyD=", &target)
+		assertErrorMessage(t, "empty value", actual)
+	})
+
+	des.with(qry.ConvertEmptyAs(qry.EmptySkip), qry.SeparateKeyChainBy('.')).runSubtest(t, "tag error mode overrides skip", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Strict int `qry:"strict,empty=error"`
+			Nested *struct {
+				Strict int `qry:"strict,empty=error"`
+			}
+		}
+
+		actual := decode("strict=", &target)
+		assertErrorMessage(t, "empty value", actual)
+
+		actual = decode("nested.strict=", &target)
+		assertErrorMessage(t, "empty value", actual)
+	})
+
+	des.runSubtest(t, "tag invalid mode", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Key int `qry:"key,empty=maybe"`
+		}
+		actual := decode("key=", &target)
+		assertErrorMessage(t, "invalid base tag directive 'empty' value 'maybe'", actual)
+	})
+
+	runner := des.withKeepEmpty()
+
+	runner.runSubtest(t, "keep empty error mode", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Strict []int `qry:"strict,empty=error"`
+		}
+		actual := decode("strict=1,,3", &target)
+		assertErrorMessage(t, "empty value", actual)
+	})
+
+	runner.runSubtest(t, "keep empty zero mode decode error", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Zeroed []int `qry:"zeroed,empty=zero"`
+		}
+		actual := decode("zeroed=1,,x", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runEmptyQueryTests(t *testing.T) {
+	dss.with(qry.ConvertEmptyAs(qry.EmptySkip), qry.SeparateKeyChainBy('.')).runSubtest(t, "skip", func(t *testing.T, decode tDecode) {
+		target := struct {
+			KeyA int
+			KeyB *string
+			KeyC map[string]int
+			KeyD map[string]map[string]int
+			KeyE *struct{ KeyX int }
+			KeyF map[string]struct{ KeyX int }
+		}{KeyA: 1}
+
+		decode("keyA=&keyB&keyC.keyX=&keyD.keyX.keyY=&keyE.keyX=&keyF.keyY.keyX=", &target)
+		assert.Equal(t, 1, target.KeyA)
+		assert.Nil(t, target.KeyB)
+		assert.Nil(t, target.KeyC)
+		assert.Nil(t, target.KeyD)
+		assert.Nil(t, target.KeyE)
+		assert.Nil(t, target.KeyF)
+	})
+
+	dss.with(qry.ConvertEmptyAs(qry.EmptySkip), qry.SeparateKeyChainBy('.')).runSubtest(t, "tag overrides skip", func(t *testing.T, decode tDecode) {
+		type tZeroed struct {
+			KeyX *int `qry:"keyX,empty=zero"`
+		}
+
+		var target struct {
+			KeyA *int `qry:"keyA,empty=zero"`
+			KeyB *tZeroed
+			KeyC map[string]tZeroed
+		}
+
+		decode("keyA=&keyB.keyX=&keyC.keyY.keyX=", &target)
+		require.NotNil(t, target.KeyA)
+		assert.Equal(t, 0, *target.KeyA)
+
+		require.NotNil(t, target.KeyB)
+		require.NotNil(t, target.KeyB.KeyX)
+		assert.Equal(t, 0, *target.KeyB.KeyX)
+
+		require.Contains(t, target.KeyC, "keyY")
+		require.NotNil(t, target.KeyC["keyY"].KeyX)
+		assert.Equal(t, 0, *target.KeyC["keyY"].KeyX)
+	})
+
+	dss.with(qry.ConvertEmptyAs(qry.EmptyZero)).runSubtest(t, "zero", func(t *testing.T, decode tDecode) {
+		target := struct {
+			KeyA int
+			KeyB **string
+			KeyC []int
+		}{KeyA: 1, KeyC: []int{1}}
+
+		decode("keyA=&keyB&keyC=", &target)
+		assert.Equal(t, 0, target.KeyA)
+		require.NotNil(t, target.KeyB)
+		require.NotNil(t, *target.KeyB)
+		assert.Equal(t, "", **target.KeyB)
+		assert.Nil(t, target.KeyC)
+	})
+
+	dss.with(qry.ConvertEmptyAs(qry.EmptyNil)).runSubtest(t, "nil", func(t *testing.T, decode tDecode) {
+		orig := "orig"
+		target := struct {
+			KeyA int
+			KeyB *string
+		}{KeyA: 1, KeyB: &orig}
+
+		decode("keyA=&keyB", &target)
+		assert.Equal(t, 0, target.KeyA)
+		assert.Nil(t, target.KeyB)
+	})
+
+	dss.runSubtest(t, "tag", func(t *testing.T, decode tDecode) {
+		target := struct {
+			KeyA int     `qry:"keyA,empty=skip"`
+			KeyB *int    `qry:"keyB,empty=zero"`
+			KeyC *int    `qry:"keyC,empty=nil"`
+			KeyE *string `qry:"keyE"`
+		}{KeyA: 1}
+
+		decode("keyA=&keyB=&keyC=&keyE=", &target)
+		assert.Equal(t, 1, target.KeyA)
+		assert.Equal(t, 0, *target.KeyB)
+		assert.Nil(t, target.KeyC)
+		assert.Equal(t, "", *target.KeyE)
+	})
+
+	for _, mode := range []qry.EmptyMode{qry.EmptySkip, qry.EmptyZero, qry.EmptyNil, qry.EmptyError} {
+		dss.with(qry.ConvertEmptyAs(mode)).runSubtest(t, "bool flag "+string(mode), func(t *testing.T, decode tDecode) {
+			var target tBoolFlag
+
+			decode("verbose&debug=", &target)
+			assert.True(t, target.Verbose)
+			require.NotNil(t, target.Debug)
+			assert.True(t, *target.Debug)
+		})
+
+		dss.with(qry.ConvertEmptyAs(mode), qry.ConvertBoolFlag(true)).runSubtest(t, "bool flag config "+string(mode), func(t *testing.T, decode tDecode) {
+			var target struct{ Verbose bool }
+
+			decode("verbose", &target)
+			assert.True(t, target.Verbose)
+		})
+	}
+
+	dss.withKeepEmpty().runSubtest(t, "keep empty", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Zeroed  []int    `qry:"zeroed,empty=zero"`
+			Skipped []int    `qry:"skipped,empty=skip"`
+			Strings []string `qry:"strings"`
+			Array   [4]int   `qry:"array,empty=zero"`
+		}
+
+		decode("zeroed=1,,3&skipped=1,,3&strings=,a,&array=1,,3", &target)
+		assert.Equal(t, []int{1, 0, 3}, target.Zeroed)
+		assert.Equal(t, []int{1, 3}, target.Skipped)
+		assert.Equal(t, []string{"", "a", ""}, target.Strings)
+		assert.Equal(t, [4]int{1, 0, 3, 0}, target.Array)
+	})
+}
+
+func (dss decodeSuccessSuite) runEmptyFieldTests(t *testing.T) {
+	dss.with(qry.ConvertEmptyAs(qry.EmptySkip)).runSubtest(t, "skip", func(t *testing.T, decode tDecode) {
+		var target map[string]int
+
+		decode("keyX=", &target)
+		assert.Nil(t, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runEmptyValueListTests(t *testing.T) {
+	dss.withKeepEmpty().runSubtest(t, "keep empty", func(t *testing.T, decode tDecode) {
+		var target []string
+
+		decode("", &target)
+		assert.Empty(t, target)
+	})
+}
@@ -0,0 +1,97 @@
+package qry_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+type tHandlerWrapper struct {
+	Valid bool
+	Value int
+}
+
+var tHandlerWrapperT = reflect.TypeOf(tHandlerWrapper{})
+
+func handleWrapper(level qry.DecodeLevel, raw string, val reflect.Value, state *qry.DecodeState) (bool, error) {
+	if val.Type() != tHandlerWrapperT {
+		return false, nil
+	}
+
+	if err := state.Decode(qry.LevelValue, raw, val.Field(1)); err != nil {
+		return true, err
+	}
+
+	val.Field(0).SetBool(true)
+	return true, nil
+}
+
+// ===== Error
+func (des decodeErrorSuite) runHandlerQueryTests(t *testing.T) {
+	des.with(qry.HandleBefore(qry.HandlerFunc(handleWrapper))).runSubtest(t, "before error", func(t *testing.T, decode tDecode) {
+		var target struct{ KeyA tHandlerWrapper }
+		actual := decode("keyA=xyz", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+}
+
+func (des decodeErrorSuite) runHandlerValueListTests(t *testing.T) {
+	runner := des.with(qry.HandleVia(qry.HandlerIndirects(), qry.HandlerLiterals()))
+
+	runner.runSubtest(t, "replace error", func(t *testing.T, decode tDecode) {
+		var target []string
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "unsupported target type", actual)
+	})
+}
+
+func (ces configErrorSuite) runHandlerTests(t *testing.T) {
+	ces.runSubtest(t, "nil handler", "nil handler", qry.HandleBefore(nil))
+	ces.runSubtest(t, "nil replacement handler", "nil handler", qry.HandleVia(qry.HandlerLiterals(), nil))
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runHandlerQueryTests(t *testing.T) {
+	dss.with(qry.HandleBefore(qry.HandlerFunc(handleWrapper))).runSubtest(t, "before", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA tHandlerWrapper
+			KeyB *tHandlerWrapper
+			KeyC tHandlerWrapper
+		}
+
+		decode("keyA=1&keyB=2", &target)
+		assert.Equal(t, tHandlerWrapper{Valid: true, Value: 1}, target.KeyA)
+		assert.Equal(t, &tHandlerWrapper{Valid: true, Value: 2}, target.KeyB)
+		assert.Equal(t, tHandlerWrapper{}, target.KeyC)
+	})
+
+	var levels []qry.DecodeLevel
+	fallback := func(level qry.DecodeLevel, _ string, _ reflect.Value, _ *qry.DecodeState) (bool, error) {
+		levels = append(levels, level)
+		return true, nil
+	}
+
+	dss.with(qry.HandleAfter(qry.HandlerFunc(fallback))).runSubtest(t, "after", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA chan int
+			KeyB []string
+		}
+
+		decode("keyA=xyz&keyB=val", &target)
+		assert.Equal(t, []qry.DecodeLevel{qry.LevelValueList}, levels)
+		assert.Equal(t, []string{"val"}, target.KeyB)
+	})
+}
+
+func (dss decodeSuccessSuite) runHandlerValueTests(t *testing.T) {
+	runner := dss.with(qry.HandleVia(qry.HandlerIndirects(), qry.HandlerLiterals()))
+
+	runner.runSubtest(t, "replace", func(t *testing.T, decode tDecode) {
+		var target *string
+
+		decode("xyz", &target)
+		assert.Equal(t, "xyz", *target)
+	})
+}
@@ -0,0 +1,167 @@
+package qry_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+func shortCircuitHook(value interface{}, calls *int) qry.DecodeHook {
+	return func(info qry.DecodeInfo) (qry.DecodeInfo, reflect.Value, error) {
+		*calls++
+		if info.Input == "default" {
+			return info, reflect.ValueOf(value), nil
+		}
+		return info, reflect.Value{}, nil
+	}
+}
+
+// ===== Error
+func (des decodeErrorSuite) runHookValueListTests(t *testing.T) {
+	var calls int
+	des.with(qry.HookDecodeVia(shortCircuitHook(42, &calls))).runSubtest(t, "short circuit type error", func(t *testing.T, decode tDecode) {
+		var target []string
+		actual := decode("default", &target)
+		assertErrorMessage(t, "decode hook value type int not assignable to []string", actual)
+	})
+
+	post := func(info qry.DecodeInfo) error {
+		if info.Input == "13" {
+			return errors.New("unlucky")
+		}
+		return nil
+	}
+
+	des.with(qry.HookPostDecodeVia(post)).runSubtest(t, "post decode error", func(t *testing.T, decode tDecode) {
+		var target []int
+		actual := decode("1,13", &target)
+		assertErrorMessage(t, "unlucky", actual)
+	})
+}
+
+func (des decodeErrorSuite) runHookValueTests(t *testing.T) {
+	failing := func(info qry.DecodeInfo) (qry.DecodeInfo, reflect.Value, error) {
+		return info, reflect.Value{}, errors.New("forced hook error")
+	}
+
+	des.with(qry.HookDecodeVia(failing)).runSubtest(t, "hook error", func(t *testing.T, decode tDecode) {
+		var target string
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "forced hook error", actual)
+	})
+
+	unsettable := func(info qry.DecodeInfo) (qry.DecodeInfo, reflect.Value, error) {
+		info.Target = reflect.ValueOf("xyz")
+		return info, reflect.Value{}, nil
+	}
+
+	des.with(qry.HookDecodeVia(unsettable)).runSubtest(t, "unsettable target error", func(t *testing.T, decode tDecode) {
+		var target string
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "decode hook target not settable", actual)
+	})
+
+	var calls int
+	des.with(qry.HookDecodeVia(shortCircuitHook(42, &calls))).runSubtest(t, "short circuit convertible error", func(t *testing.T, decode tDecode) {
+		var target int32
+		actual := decode("default", &target)
+		assertErrorMessage(t, "decode hook value type int not assignable to int32", actual)
+	})
+
+	substitute := func(info qry.DecodeInfo) (qry.DecodeInfo, reflect.Value, error) {
+		if info.Target.Kind() == reflect.Int32 {
+			info.Target = reflect.New(reflect.TypeOf(int64(0))).Elem()
+		}
+		return info, reflect.Value{}, nil
+	}
+
+	des.with(qry.HookDecodeVia(substitute)).runSubtest(t, "substitute convertible error", func(t *testing.T, decode tDecode) {
+		var target int32
+		actual := decode("42", &target)
+		assertErrorMessage(t, "decode hook value type int64 not assignable to int32", actual)
+	})
+}
+
+func (ces configErrorSuite) runHookTests(t *testing.T) {
+	ces.runSubtest(t, "nil decode hook", "nil decode hook", qry.HookDecodeVia(nil))
+	ces.runSubtest(t, "nil post decode hook", "nil post decode hook", qry.HookPostDecodeVia(nil))
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runHookQueryTests(t *testing.T) {
+	substitute := func(info qry.DecodeInfo) (qry.DecodeInfo, reflect.Value, error) {
+		if info.Level == qry.LevelValueList && info.Target.Kind() == reflect.Interface {
+			info.Target = reflect.New(reflect.TypeOf([]int{})).Elem()
+		}
+		return info, reflect.Value{}, nil
+	}
+
+	dss.with(qry.HookDecodeVia(substitute)).runSubtest(t, "substitute", func(t *testing.T, decode tDecode) {
+		var target struct{ KeyA interface{} }
+
+		decode("keyA=1,2", &target)
+		assert.Equal(t, []int{1, 2}, target.KeyA)
+	})
+}
+
+func (dss decodeSuccessSuite) runHookValueListTests(t *testing.T) {
+	rewrite := func(info qry.DecodeInfo) (qry.DecodeInfo, reflect.Value, error) {
+		if info.Level == qry.LevelValue && info.Input == "legacy" {
+			info.Input = "modern"
+		}
+		return info, reflect.Value{}, nil
+	}
+
+	dss.with(qry.HookDecodeVia(rewrite)).runSubtest(t, "rewrite", func(t *testing.T, decode tDecode) {
+		var target []string
+
+		decode("legacy,other", &target)
+		assert.Equal(t, []string{"modern", "other"}, target)
+	})
+
+	var values []interface{}
+	post := func(info qry.DecodeInfo) error {
+		if info.Level == qry.LevelValue {
+			values = append(values, info.Target.Interface())
+		}
+		return nil
+	}
+
+	dss.with(qry.HookPostDecodeVia(post)).runSubtest(t, "post decode", func(t *testing.T, decode tDecode) {
+		var target []int
+
+		decode("1,2", &target)
+		assert.Equal(t, []interface{}{1, 2}, values)
+	})
+
+	var substitutions int
+	sameType := func(info qry.DecodeInfo) (qry.DecodeInfo, reflect.Value, error) {
+		if info.Target.Kind() == reflect.Int {
+			substitutions++
+			info.Target = reflect.New(info.Target.Type()).Elem()
+		}
+		return info, reflect.Value{}, nil
+	}
+
+	dss.with(qry.HookDecodeVia(sameType)).runSubtest(t, "substitute same type", func(t *testing.T, decode tDecode) {
+		var target []int
+
+		decode("1,2", &target)
+		assert.Equal(t, []int{1, 2}, target)
+		assert.Equal(t, 2, substitutions, "check hook run once per value")
+	})
+}
+
+func (dss decodeSuccessSuite) runHookValueTests(t *testing.T) {
+	var calls int
+	dss.with(qry.HookDecodeVia(shortCircuitHook(int32(42), &calls), shortCircuitHook(int32(0), &calls))).runSubtest(t, "short circuit", func(t *testing.T, decode tDecode) {
+		var target int32
+
+		decode("default", &target)
+		assert.Equal(t, int32(42), target)
+		assert.Equal(t, 1, calls, "check subsequent hooks skipped")
+	})
+}
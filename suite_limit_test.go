@@ -0,0 +1,104 @@
+package qry_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+// ===== Error
+func assertLimitError(t *testing.T, name qry.LimitName, max int, actual error) bool {
+	var limitErr qry.LimitError
+	if !assertErrorAs(t, &limitErr, actual) {
+		return false
+	}
+	return assert.Equal(t, qry.LimitError{Name: name, Max: max}, limitErr)
+}
+
+func (des decodeErrorSuite) runLimitQueryTests(t *testing.T) {
+	des.with(qry.LimitInputLengthTo(8)).runSubtest(t, "input length", func(t *testing.T, decode tDecode) {
+		var target map[string][]string
+		actual := decode("keyA=valA&keyB=valB", &target)
+		assertLimitError(t, qry.LimitInputLength, 8, actual)
+	})
+
+	des.with(qry.LimitFieldsTo(2)).runSubtest(t, "fields", func(t *testing.T, decode tDecode) {
+		var target map[string][]string
+		actual := decode("keyA=valA&keyB=valB&keyC=valC", &target)
+		assertLimitError(t, qry.LimitFields, 2, actual)
+	})
+
+	des.with(qry.LimitFieldsTo(2)).runSubtest(t, "fields list", func(t *testing.T, decode tDecode) {
+		var target []string
+		actual := decode("valA&valB&valC", &target)
+		assertLimitError(t, qry.LimitFields, 2, actual)
+	})
+
+	des.with(qry.LimitValuesTo(2)).runSubtest(t, "values", func(t *testing.T, decode tDecode) {
+		var target map[string][]string
+		actual := decode("keyA=val1,val2,val3", &target)
+		assertLimitError(t, qry.LimitValues, 2, actual)
+	})
+
+	for name, sepOpt := range map[string]qry.Option{
+		"values keep empty":     qry.SeparateValuesKeepEmptyBy(','),
+		"values string":         qry.SeparateValuesByString(","),
+		"values regexp":         qry.SeparateValuesByRegexp(regexp.MustCompile(`,`)),
+		"values escaped":        qry.SeparateValuesEscapedBy(','),
+		"values quoted":         qry.SeparateValuesQuotedBy(','),
+		"values quoted escaped": qry.SeparateValuesQuotedEscapedBy(','),
+	} {
+		des.with(sepOpt, qry.LimitValuesTo(2)).runSubtest(t, name, func(t *testing.T, decode tDecode) {
+			var target map[string][]string
+			actual := decode("keyA=val1,val2,val3", &target)
+			assertLimitError(t, qry.LimitValues, 2, actual)
+		})
+	}
+
+	des.with(
+		qry.SeparateKeyChainBy('.'),
+		qry.LimitKeyChainDepthTo(2),
+	).runSubtest(t, "key chain depth", func(t *testing.T, decode tDecode) {
+		var target map[string]map[string]map[string]string
+		actual := decode("keyA.keyB.keyC=valABC", &target)
+		assertLimitError(t, qry.LimitKeyChainDepth, 2, actual)
+	})
+
+	des.with(
+		qry.SeparateKeyChainBy('.'),
+		qry.LimitMapEntriesTo(3),
+	).runSubtest(t, "map entries", func(t *testing.T, decode tDecode) {
+		var target map[string]map[string]string
+		actual := decode("keyA.keyX=valAX&keyA.keyY=valAY&keyB.keyX=valBX", &target)
+		assertLimitError(t, qry.LimitMapEntries, 3, actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runLimitQueryTests(t *testing.T) {
+	runner := dss.with(
+		qry.SeparateKeyChainBy('.'),
+		qry.LimitInputLengthTo(64),
+		qry.LimitFieldsTo(3),
+		qry.LimitValuesTo(2),
+		qry.LimitKeyChainDepthTo(2),
+		qry.LimitMapEntriesTo(4),
+	)
+
+	runner.runSubtest(t, "within limits", func(t *testing.T, decode tDecode) {
+		var (
+			target   map[string]map[string][]string
+			expected = map[string]map[string][]string{
+				"keyA": {"keyX": {"val1", "val2"}},
+				"keyB": {"keyX": {"val3", "val4"}},
+			}
+		)
+
+		// NOTE: Updating an existing entry (keyA.keyX) does not count against
+		// the map entry limit
+		decode("keyA.keyX=val1&keyB.keyX=val3,val4&keyA.keyX=val2", &target)
+		assert.Equal(t, expected, target)
+	})
+}
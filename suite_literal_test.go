@@ -224,4 +224,20 @@ func (dss decodeSuccessSuite) runLiteralFloatLikeSubtests(t *testing.T) {
 			assert.Equal(t, complex(float64(expected), 0), target)
 		})
 	}
+
+	// Basic complex with imaginary part
+	dss.runSubtest(t, "complex64 imaginary target", func(t *testing.T, decode tDecode) {
+		var target complex64
+		decode("2.718-1.5i", &target)
+		assert.Equal(t, complex(float32(expected), -1.5), target)
+	})
+
+	// Extended complex with imaginary part
+	if !testing.Short() {
+		dss.runSubtest(t, "complex128 imaginary target", func(t *testing.T, decode tDecode) {
+			var target complex128
+			decode("(2.718%2B1.5i)", &target)
+			assert.Equal(t, complex(float64(expected), 1.5), target)
+		})
+	}
 }
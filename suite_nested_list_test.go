@@ -0,0 +1,103 @@
+package qry_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	tTree    []tTree
+	tPtrLoop *tPtrLoop
+)
+
+func (dr decodeRunner) withNestedValues() decodeRunner {
+	return dr.with(
+		qry.LimitValuesTo(3),
+		qry.SeparateKeyChainBy('.'),
+		qry.SeparateNestedValuesBy('|', ';'),
+	)
+}
+
+// ===== Error
+func (des decodeErrorSuite) runNestedListQueryTests(t *testing.T) {
+	runner := des.withNestedValues()
+
+	runner.runSubtest(t, "array length error", func(t *testing.T, decode tDecode) {
+		var target struct{ Polygon [][2]float64 }
+		actual := decode("polygon=1,2,3", &target)
+		assertErrorMessage(t, "insufficient destination array length", actual)
+	})
+
+	runner.runSubtest(t, "values limit", func(t *testing.T, decode tDecode) {
+		var target struct{ Matrix [][]int }
+		actual := decode("matrix=1;2;3;4", &target)
+		assertLimitError(t, qry.LimitValues, 3, actual)
+	})
+
+	des.with(qry.SeparateKeyComponentsBy(':')).runSubtest(t, "recursive pointer key error", func(t *testing.T, decode tDecode) {
+		var target map[[2]tPtrLoop]string
+		actual := decode("a:b=x", &target)
+		assertErrorMessage(t, "unsupported target type", actual)
+	})
+}
+
+func (des decodeErrorSuite) runNestedListValueListTests(t *testing.T) {
+	des.with(qry.SeparateNestedValuesBy(';')).runSubtest(t, "insufficient separators error", func(t *testing.T, decode tDecode) {
+		var target [][][]int
+		actual := decode("1", &target)
+		assertErrorMessage(t, "unsupported target type", actual)
+	})
+
+	for name, runner := range map[string]decodeRunner{
+		"":               des.with(),
+		" nested values": des.withNestedValues(),
+	} {
+		runner.runSubtest(t, "recursive slice error"+name, func(t *testing.T, decode tDecode) {
+			var target tTree
+			actual := decode("1,2", &target)
+			assertErrorMessage(t, "unsupported target type", actual)
+		})
+
+		runner.runSubtest(t, "recursive pointer error"+name, func(t *testing.T, decode tDecode) {
+			var target []tPtrLoop
+			actual := decode("1,2", &target)
+			assertErrorMessage(t, "unsupported target type", actual)
+		})
+	}
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runNestedListQueryTests(t *testing.T) {
+	dss.withNestedValues().runSubtest(t, "nested", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Matrix  [][]int
+			Polygon [][2]float64
+			Fixed   [2][2]int
+			Cube    [][][]int
+			Named   map[string][][]string
+			Flat    []string
+			Bytes   [][]byte
+		}
+
+		decode("matrix=1,2;3,4&polygon=0,0;1,0;1,1&fixed=1,2;3,4&cube=1,2;3|4;5,6"+
+			"&named.x=a,b;c&flat=a;b,c&bytes=ab,cd", &target)
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}}, target.Matrix)
+		assert.Equal(t, [][2]float64{{0, 0}, {1, 0}, {1, 1}}, target.Polygon)
+		assert.Equal(t, [2][2]int{{1, 2}, {3, 4}}, target.Fixed)
+		assert.Equal(t, [][][]int{{{1, 2}, {3}}, {{4}, {5, 6}}}, target.Cube)
+		assert.Equal(t, map[string][][]string{"x": {{"a", "b"}, {"c"}}}, target.Named)
+		assert.Equal(t, []string{"a;b", "c"}, target.Flat)
+		assert.Equal(t, [][]byte{[]byte("ab"), []byte("cd")}, target.Bytes)
+	})
+}
+
+func (dss decodeSuccessSuite) runNestedListValueListTests(t *testing.T) {
+	dss.withNestedValues().runSubtest(t, "nested", func(t *testing.T, decode tDecode) {
+		var target [][]int
+
+		decode("1,2;3", &target)
+		assert.Equal(t, [][]int{{1, 2}, {3}}, target)
+	})
+}
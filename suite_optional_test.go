@@ -0,0 +1,122 @@
+package qry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oligarch316/qry"
+	"github.com/oligarch316/qry/optional"
+	"github.com/stretchr/testify/assert"
+)
+
+// ===== Error
+func (des decodeErrorSuite) runOptionalQueryTests(t *testing.T) {
+	des.runSubtest(t, "value error", func(t *testing.T, decode tDecode) {
+		var target struct{ KeyA optional.Optional[[]int] }
+		actual := decode("keyA=x", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+
+	des.runSubtest(t, "tag base error", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA optional.Optional[int] `qry:"keyA,base=2"`
+		}
+		actual := decode("keyA=12", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+
+	des.withSetOpts(qry.SetDisallowLiteral).runSubtest(t, "disallow literal error", func(t *testing.T, decode tDecode) {
+		var target optional.Optional[string]
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "unsupported target type", actual)
+	})
+
+	des.with(qry.LimitValuesTo(2)).runSubtest(t, "values limit", func(t *testing.T, decode tDecode) {
+		var target struct{ KeyA optional.Optional[[]int] }
+		actual := decode("keyA=1,2,3", &target)
+		assertLimitError(t, qry.LimitValues, 2, actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runOptionalQueryTests(t *testing.T) {
+	dss.runSubtest(t, "states", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA optional.Optional[[]int]
+			KeyB optional.Optional[[]int]
+			KeyC optional.Optional[[]int]
+			KeyD optional.Optional[[]string]
+		}
+
+		decode("keyA=1,2&keyB=&keyD", &target)
+
+		value, ok := target.KeyA.Get()
+		assert.True(t, ok)
+		assert.Equal(t, []int{1, 2}, value)
+		assert.Equal(t, optional.Present, target.KeyA.State)
+
+		assert.True(t, target.KeyB.IsEmpty(), "check key with empty value")
+		assert.True(t, target.KeyC.IsAbsent(), "check missing key")
+		assert.True(t, target.KeyD.IsEmpty(), "check key without value")
+	})
+
+	dss.runSubtest(t, "non-slice", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA optional.Optional[int]
+			KeyB optional.Optional[string]
+			KeyC optional.Optional[*float64]
+		}
+
+		decode("keyA=42&keyB=val%20B&keyC=1.5", &target)
+		assert.Equal(t, optional.Optional[int]{Value: 42, State: optional.Present}, target.KeyA)
+		assert.Equal(t, optional.Optional[string]{Value: "val B", State: optional.Present}, target.KeyB)
+
+		if value, ok := target.KeyC.Get(); assert.True(t, ok) && assert.NotNil(t, value) {
+			assert.Equal(t, 1.5, *value)
+		}
+	})
+
+	dss.runSubtest(t, "tagged", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Color optional.Optional[uint32]    `qry:"color,base=16"`
+			Date  optional.Optional[time.Time] `qry:"date,layout=2006-01-02"`
+			IDs   optional.Optional[[]int]     `qry:"ids,sep=|"`
+		}
+
+		decode("color=ff00ff&date=2020-01-02&ids=1|2|3", &target)
+		assert.Equal(t, optional.Optional[uint32]{Value: 0xff00ff, State: optional.Present}, target.Color)
+		assert.Equal(t, optional.Optional[time.Time]{Value: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), State: optional.Present}, target.Date)
+		assert.Equal(t, optional.Optional[[]int]{Value: []int{1, 2, 3}, State: optional.Present}, target.IDs)
+	})
+
+	runner := dss.with(qry.SetLevelVia(qry.LevelValueList, qry.SetDisallowLiteral))
+	runner.runSubtest(t, "tagged set options", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA optional.Optional[string] `qrySet:"allowLiteral"`
+		}
+
+		decode("keyA=xyz", &target)
+		assert.Equal(t, optional.Optional[string]{Value: "xyz", State: optional.Present}, target.KeyA)
+	})
+}
+
+func (dss decodeSuccessSuite) runOptionalValueListTests(t *testing.T) {
+	dss.runSubtest(t, "list", func(t *testing.T, decode tDecode) {
+		var target []optional.Optional[int]
+
+		decode("1,2", &target)
+		assert.Equal(t, []optional.Optional[int]{
+			{Value: 1, State: optional.Present},
+			{Value: 2, State: optional.Present},
+		}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runOptionalValueTests(t *testing.T) {
+	dss.runSubtest(t, "state string", func(t *testing.T, _ tDecode) {
+		assert.Equal(t, "absent", optional.Absent.String())
+		assert.Equal(t, "empty", optional.Empty.String())
+		assert.Equal(t, "present", optional.Present.String())
+		assert.Equal(t, "invalid", optional.State(-1).String())
+	})
+}
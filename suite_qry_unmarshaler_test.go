@@ -0,0 +1,188 @@
+package qry_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	tOrderedSet []int
+	tSplit      []string
+	tContextVal string
+)
+
+func (tos *tOrderedSet) UnmarshalQry(level qry.DecodeLevel, raw string, state *qry.DecodeState) error {
+	if level != qry.LevelValueList {
+		return errors.New("ordered set not a value list")
+	}
+
+	rawItems, err := state.Split(level, raw)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[int]bool)
+	for _, rawItem := range rawItems {
+		var item int
+		if err := state.Decode(qry.LevelValue, rawItem, reflect.ValueOf(&item).Elem()); err != nil {
+			return err
+		}
+
+		if !seen[item] {
+			seen[item] = true
+			*tos = append(*tos, item)
+		}
+	}
+	return nil
+}
+
+func (ts *tSplit) UnmarshalQry(level qry.DecodeLevel, raw string, state *qry.DecodeState) error {
+	res, err := state.Split(level, raw)
+	if err != nil {
+		return err
+	}
+
+	*ts = res
+	return nil
+}
+
+func (tcv *tContextVal) UnmarshalQry(_ qry.DecodeLevel, _ string, state *qry.DecodeState) error {
+	val, _ := state.Context().Value(tContextKey{}).(string)
+	*tcv = tContextVal(val)
+	return nil
+}
+
+// ===== Error
+func (des decodeErrorSuite) runQryUnmarshalerQueryTests(t *testing.T) {
+	des.runSubtest(t, "item error", func(t *testing.T, decode tDecode) {
+		var target struct{ Set tOrderedSet }
+		actual := decode("set=1,x", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+
+		// Already a DecodeError (per the item) => not wrapped again
+		var decodeErr qry.DecodeError
+		assert.False(t, errors.As(actual, &decodeErr), "nested decode error")
+	})
+
+	des.with(qry.LimitValuesTo(4)).runSubtest(t, "values limit", func(t *testing.T, decode tDecode) {
+		var target struct{ Set tOrderedSet }
+		actual := decode("set=1,2,3,4,5", &target)
+		assertLimitError(t, qry.LimitValues, 4, actual)
+	})
+
+	des.with(qry.LimitValuesTo(2)).runSubtest(t, "tag separator values limit", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Set tOrderedSet `qry:"set,sep=|"`
+		}
+		actual := decode("set=1|2|3", &target)
+		assertLimitError(t, qry.LimitValues, 2, actual)
+	})
+}
+
+func (des decodeErrorSuite) runQryUnmarshalerKeyTests(t *testing.T) {
+	des.with(qry.SeparateKeyChainBy('.'), qry.LimitKeyChainDepthTo(2)).runSubtest(t, "split key chain depth limit", func(t *testing.T, decode tDecode) {
+		var target tSplit
+		actual := decode("a.b.c", &target)
+		assertLimitError(t, qry.LimitKeyChainDepth, 2, actual)
+	})
+}
+
+func (des decodeErrorSuite) runQryUnmarshalerValueTests(t *testing.T) {
+	des.runSubtest(t, "level error", func(t *testing.T, decode tDecode) {
+		var target tOrderedSet
+		actual := decode("1", &target)
+		assertErrorMessage(t, "ordered set not a value list", actual)
+	})
+
+	des.runSubtest(t, "split level error", func(t *testing.T, decode tDecode) {
+		var target tSplit
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "invalid split level: value", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runQryUnmarshalerQueryTests(t *testing.T) {
+	dss.runSubtest(t, "ordered set", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Set    tOrderedSet
+			SetPtr *tOrderedSet
+		}
+
+		decode("set=3,1,3,2&setPtr=5,5", &target)
+		assert.Equal(t, tOrderedSet{3, 1, 2}, target.Set)
+		assert.Equal(t, &tOrderedSet{5}, target.SetPtr)
+	})
+
+	dss.runSubtest(t, "tag separator", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Set tOrderedSet `qry:"set,sep=|"`
+		}
+
+		decode("set=3|1|3", &target)
+		assert.Equal(t, tOrderedSet{3, 1}, target.Set)
+	})
+
+	dss.with(qry.ConvertIntegerBaseAs(16)).runSubtest(t, "tag base", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Set tOrderedSet `qry:"set,base=10"`
+		}
+
+		decode("set=10,16", &target)
+		assert.Equal(t, tOrderedSet{10, 16}, target.Set)
+	})
+
+	ctx := context.WithValue(context.Background(), tContextKey{}, "xyz")
+	dss.withContext(ctx).runSubtest(t, "context", func(t *testing.T, decode tDecode) {
+		var target struct{ Val tContextVal }
+
+		decode("val=abc", &target)
+		assert.Equal(t, tContextVal("xyz"), target.Val)
+	})
+
+	dss.runSubtest(t, "split", func(t *testing.T, decode tDecode) {
+		var target tSplit
+
+		decode("a=1&b=2", &target)
+		assert.Equal(t, tSplit{"a=1", "b=2"}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runQryUnmarshalerFieldTests(t *testing.T) {
+	dss.runSubtest(t, "split", func(t *testing.T, decode tDecode) {
+		var target tSplit
+
+		decode("a=1,2", &target)
+		assert.Equal(t, tSplit{"a", "1,2"}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runQryUnmarshalerKeyTests(t *testing.T) {
+	dss.withKeyChainSep('.').runSubtest(t, "split", func(t *testing.T, decode tDecode) {
+		var target tSplit
+
+		decode("a.b", &target)
+		assert.Equal(t, tSplit{"a", "b"}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runQryUnmarshalerValueListTests(t *testing.T) {
+	dss.runSubtest(t, "ordered set", func(t *testing.T, decode tDecode) {
+		var target tOrderedSet
+
+		decode("3,1,3,2", &target)
+		assert.Equal(t, tOrderedSet{3, 1, 2}, target)
+	})
+
+	dss.runSubtest(t, "split", func(t *testing.T, decode tDecode) {
+		var target tSplit
+
+		decode("1,2", &target)
+		assert.Equal(t, tSplit{"1", "2"}, target)
+	})
+}
@@ -0,0 +1,330 @@
+package qry_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+func (dr decodeRunner) withStringSeparators() decodeRunner {
+	return dr.with(
+		qry.SeparateFieldsByString("&&"),
+		qry.SeparateKeyValsByString(":="),
+		qry.SeparateKeyChainByString("::"),
+		qry.SeparateValuesByString("|~|", "|", ""),
+	)
+}
+
+func (dr decodeRunner) withRegexpSeparators() decodeRunner {
+	return dr.with(
+		qry.SeparateFieldsByRegexp(regexp.MustCompile(`[&;]`)),
+		qry.SeparateKeyValsByRegexp(regexp.MustCompile(`=+`)),
+		qry.SeparateKeyChainByRegexp(regexp.MustCompile(`\.|::`)),
+		qry.SeparateValuesByRegexp(regexp.MustCompile(`\s*,\s*`)),
+	)
+}
+
+type tChainSepNested struct {
+	Mid struct {
+		Inner struct {
+			Leaf map[string]string
+		} `qry:"inner,chainSep=:"`
+	} `qry:"mid,chainSep=/"`
+}
+
+// ===== Config error
+func (ces configErrorSuite) runSeparateRegexpTests(t *testing.T) {
+	for name, opt := range map[string]func(*regexp.Regexp) qry.Option{
+		"fields":         qry.SeparateFieldsByRegexp,
+		"key vals":       qry.SeparateKeyValsByRegexp,
+		"key chain":      qry.SeparateKeyChainByRegexp,
+		"key components": qry.SeparateKeyComponentsByRegexp,
+		"values":         qry.SeparateValuesByRegexp,
+	} {
+		ces.runSubtest(t, name+" nil", "nil separator regexp", opt(nil))
+		ces.runSubtest(t, name+" empty match", "separator regexp ',*' matches empty string", opt(regexp.MustCompile(`,*`)))
+	}
+
+	// Superseded by an option for another separator => still invalid
+	ces.runSubtest(t, "other separator", "nil separator regexp", qry.SeparateValuesByRegexp(nil), qry.SeparateFieldsBy('&'))
+
+	for _, expr := range []string{``, `a?`, `\b`, `^`, `(,|)`, `(?:,){0,2}`, `(,*)+`} {
+		ces.runSubtest(
+			t, "empty match "+expr,
+			fmt.Sprintf("separator regexp '%s' matches empty string", expr),
+			qry.SeparateValuesByRegexp(regexp.MustCompile(expr)),
+		)
+	}
+
+	ces.runSubtest(
+		t, "empty match posix",
+		"separator regexp ',*' matches empty string",
+		qry.SeparateValuesByRegexp(regexp.MustCompilePOSIX(`,*`)),
+	)
+}
+
+// ===== Error
+func (des decodeErrorSuite) runSeparateTagQueryTests(t *testing.T) {
+	runner := des.with(qry.SeparateKeyChainBy('.'), qry.LimitKeyChainDepthTo(3))
+
+	runner.runSubtest(t, "chain depth limit", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Nested struct {
+				Inner map[string][]string
+			} `qry:"nested,chainSep=/"`
+		}
+
+		actual := decode("nested.inner/x/y=val", &target)
+		assertLimitError(t, qry.LimitKeyChainDepth, 3, actual)
+	})
+
+	runner.runSubtest(t, "nested chain depth limit", func(t *testing.T, decode tDecode) {
+		var target tChainSepNested
+		actual := decode("mid.inner/leaf:x=val", &target)
+		assertLimitError(t, qry.LimitKeyChainDepth, 3, actual)
+	})
+
+	des.with(qry.LimitKeyChainDepthTo(3)).runSubtest(t, "tag only chain depth limit", func(t *testing.T, decode tDecode) {
+		var target tChainSepNested
+		actual := decode("mid/inner:leaf:x=val", &target)
+		assertLimitError(t, qry.LimitKeyChainDepth, 3, actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runSeparateEscapedQueryTests(t *testing.T) {
+	runner := dss.with(
+		qry.SeparateKeyChainQuotedEscapedBy('.'),
+		qry.SeparateValuesQuotedEscapedBy(','),
+	)
+
+	runner.runSubtest(t, "decode", func(t *testing.T, decode tDecode) {
+		var target map[string][]string
+
+		decode(`"a.b"="x,y",z%2C&c\.d=1&%22e%22=%5C"2,3"`, &target)
+		assert.Equal(t, map[string][]string{
+			"a.b": {"x,y", "z,"},
+			"c.d": {"1"},
+			`"e"`: {`\2,3`},
+		}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runSeparateEscapedValueListTests(t *testing.T) {
+	for input, expected := range map[string]tSplit{
+		`a,b`:          {"a", "b"},
+		`a\,b,c`:       {"a,b", "c"},
+		`a%5C,b,c`:     {"a%5C", "b", "c"},
+		`a%2Cb,c`:      {"a%2Cb", "c"},
+		`a\\,b`:        {`a\`, "b"},
+		`a\%2C,b`:      {"a%2C", "b"},
+		`"a,b"`:        {`"a`, `b"`},
+		`,,a,,`:        {"a"},
+		`a\`:           {"a"},
+		`三\,四,五`:       {"三,四", "五"},
+		``:             nil,
+		`%zz,%5c%5c,b`: {"%zz", "%5c%5c", "b"},
+	} {
+		input, expected := input, expected
+
+		dss.with(qry.SeparateValuesEscapedBy(',')).runSubtest(t, "escaped "+input, func(t *testing.T, decode tDecode) {
+			var target tSplit
+
+			decode(input, &target)
+			assert.Equal(t, expected, target)
+		})
+	}
+
+	for input, expected := range map[string]tSplit{
+		`"a,b",c`:          {"a,b", "c"},
+		`%22a,b%22,c`:      {"%22a", "b%22", "c"},
+		`a"b,c"d,e`:        {"ab,cd", "e"},
+		`"",a,""`:          {"", "a", ""},
+		`"a,b`:             {"a,b"},
+		`a\,b`:             {`a\`, "b"},
+		`\"a,b\"`:          {`\a,b\`},
+		`"%2C",%5C%22x%22`: {"%2C", "%5C%22x%22"},
+	} {
+		input, expected := input, expected
+
+		dss.with(qry.SeparateValuesQuotedBy(',')).runSubtest(t, "quoted "+input, func(t *testing.T, decode tDecode) {
+			var target tSplit
+
+			decode(input, &target)
+			assert.Equal(t, expected, target)
+		})
+	}
+
+	for input, expected := range map[string]tSplit{
+		`"a,b",c`:  {"a,b", "c"},
+		`"a\"b",c`: {`a"b`, "c"},
+		`a\,b,"c"`: {"a,b", "c"},
+		`\"a,b\"`:  {`"a`, `b"`},
+		`"a\\",b`:  {`a\`, "b"},
+		`%5C"a,b"`: {"%5Ca,b"},
+	} {
+		input, expected := input, expected
+
+		dss.with(qry.SeparateValuesQuotedEscapedBy(',')).runSubtest(t, "quoted escaped "+input, func(t *testing.T, decode tDecode) {
+			var target tSplit
+
+			decode(input, &target)
+			assert.Equal(t, expected, target)
+		})
+	}
+}
+
+func (dss decodeSuccessSuite) runSeparateStringQueryTests(t *testing.T) {
+	dss.withStringSeparators().runSubtest(t, "decode", func(t *testing.T, decode tDecode) {
+		var target map[string]map[string][]string
+
+		decode("a::b:=x|~|y&&c::d:=z=w&&&&", &target)
+		assert.Equal(t, map[string]map[string][]string{
+			"a": {"b": {"x", "y"}},
+			"c": {"d": {"z=w"}},
+		}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runSeparateStringValueListTests(t *testing.T) {
+	for input, expected := range map[string]tSplit{
+		"a|~|b|c":     {"a", "b", "c"},
+		"|~||~|a||b|": {"a", "b"},
+		"a|~b":        {"a", "~b"},
+		"":            nil,
+	} {
+		input, expected := input, expected
+
+		dss.withStringSeparators().runSubtest(t, "split "+input, func(t *testing.T, decode tDecode) {
+			var target tSplit
+
+			decode(input, &target)
+			assert.Equal(t, expected, target)
+		})
+	}
+}
+
+func (dss decodeSuccessSuite) runSeparateRegexpQueryTests(t *testing.T) {
+	dss.withRegexpSeparators().runSubtest(t, "decode", func(t *testing.T, decode tDecode) {
+		var target map[string]map[string][]string
+
+		decode("a.b==x,y;c::d=z", &target)
+		assert.Equal(t, map[string]map[string][]string{
+			"a": {"b": {"x", "y"}},
+			"c": {"d": {"z"}},
+		}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runSeparateRegexpValueListTests(t *testing.T) {
+	for input, expected := range map[string]tSplit{
+		"a , b,c":  {"a", "b", "c"},
+		", a ,, b": {"a", "b"},
+		"":         nil,
+	} {
+		input, expected := input, expected
+
+		dss.withRegexpSeparators().runSubtest(t, "split "+input, func(t *testing.T, decode tDecode) {
+			var target tSplit
+
+			decode(input, &target)
+			assert.Equal(t, expected, target)
+		})
+	}
+
+	// Superseded by an option for the same separator => valid
+	dss.with(qry.SeparateValuesByRegexp(nil), qry.SeparateValuesBy(',')).runSubtest(t, "superseded invalid", func(t *testing.T, decode tDecode) {
+		var target tSplit
+
+		decode("a,b", &target)
+		assert.Equal(t, tSplit{"a", "b"}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runSeparateTagQueryTests(t *testing.T) {
+	runner := dss.with(qry.SeparateKeyChainBy('.'), qry.LimitKeyChainDepthTo(4))
+
+	runner.runSubtest(t, "values", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Tags  []string            `qry:"tags"`
+			Path  []string            `qry:"path,sep=/"`
+			IDs   []int               `qry:"ids,sep=|"`
+			Pairs map[string][]string `qry:"pairs,sep=%2C%20"`
+		}
+
+		decode("tags=a,b,c&path=a/b/c&ids=1|2|3&pairs.x=a, b,c", &target)
+		assert.Equal(t, []string{"a", "b", "c"}, target.Tags)
+		assert.Equal(t, []string{"a", "b", "c"}, target.Path)
+		assert.Equal(t, []int{1, 2, 3}, target.IDs)
+		assert.Equal(t, map[string][]string{"x": {"a", "b,c"}}, target.Pairs)
+	})
+
+	runner.runSubtest(t, "values nested struct", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Outer struct {
+				A []string
+				B []string `qry:"b,sep=;"`
+			} `qry:"outer,sep=|"`
+		}
+
+		decode("outer.a=x|y,z&outer.b=x|y;z", &target)
+		assert.Equal(t, []string{"x|y", "z"}, target.Outer.A)
+		assert.Equal(t, []string{"x|y", "z"}, target.Outer.B)
+	})
+
+	runner.runSubtest(t, "values nested slice", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Lists [][]int  `qry:"lists,sep=|"`
+			Ptr   *[][]int `qry:"ptr,sep=|"`
+		}
+
+		decode("lists=1,2|3&ptr=4|5,6", &target)
+		assert.Equal(t, [][]int{{1, 2}, {3}}, target.Lists)
+		assert.Equal(t, &[][]int{{4}, {5, 6}}, target.Ptr)
+	})
+
+	runner.with(qry.SeparateNestedValuesBy(';')).runSubtest(t, "values nested slice separators", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Lists [][]int   `qry:"lists,sep=|"`
+			Grid  [][][]int `qry:"grid,sep=|"`
+		}
+
+		decode("lists=1,2|3&grid=1,2;3|4", &target)
+		assert.Equal(t, [][]int{{1, 2}, {3}}, target.Lists)
+		assert.Equal(t, [][][]int{{{1, 2}, {3}}, {{4}}}, target.Grid)
+	})
+
+	runner.runSubtest(t, "chain", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Nested struct {
+				Inner map[string][]string
+			} `qry:"nested,chainSep=/"`
+		}
+
+		decode("nested.inner/x=val", &target)
+		assert.Equal(t, map[string][]string{"x": {"val"}}, target.Nested.Inner)
+	})
+
+	runner.runSubtest(t, "nested chain", func(t *testing.T, decode tDecode) {
+		var target tChainSepNested
+
+		decode("mid.inner/leaf:x=val", &target)
+		assert.Equal(t, map[string]string{"x": "val"}, target.Mid.Inner.Leaf)
+	})
+
+	dss.runSubtest(t, "tag only chain", func(t *testing.T, decode tDecode) {
+		var target struct {
+			tChainSepNested
+			Direct  string `qry:"mid/direct"`
+			Escaped string `qry:"mid/escaped,chainSep=/"`
+		}
+
+		decode("mid/inner:leaf:x=val&mid/direct=valD&mid%2Fescaped=valE", &target)
+		assert.Equal(t, map[string]string{"x": "val"}, target.Mid.Inner.Leaf)
+		assert.Equal(t, "valD", target.Direct)
+		assert.Equal(t, "valE", target.Escaped)
+	})
+}
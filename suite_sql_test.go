@@ -0,0 +1,60 @@
+package qry_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ===== Error
+func (des decodeErrorSuite) runSQLNullValueTests(t *testing.T) {
+	des.runSubtest(t, "invalid error", func(t *testing.T, decode tDecode) {
+		var target sql.NullInt32
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runSQLNullQueryTests(t *testing.T) {
+	dss.runSubtest(t, "valid", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Bool    sql.NullBool
+			Byte    sql.NullByte
+			Float64 sql.NullFloat64
+			Int16   sql.NullInt16
+			Int32   sql.NullInt32
+			Int64   sql.NullInt64
+			String  sql.NullString
+			Time    sql.NullTime `qry:"time,layout=2006-01-02"`
+			List    []sql.NullInt64
+		}
+
+		decode("bool=true&byte=7&float64=1.5&int16=-16&int32=32&int64=64&string=val%20S&time=2020-01-02&list=1,,2", &target)
+		assert.Equal(t, sql.NullBool{Bool: true, Valid: true}, target.Bool)
+		assert.Equal(t, sql.NullByte{Byte: 7, Valid: true}, target.Byte)
+		assert.Equal(t, sql.NullFloat64{Float64: 1.5, Valid: true}, target.Float64)
+		assert.Equal(t, sql.NullInt16{Int16: -16, Valid: true}, target.Int16)
+		assert.Equal(t, sql.NullInt32{Int32: 32, Valid: true}, target.Int32)
+		assert.Equal(t, sql.NullInt64{Int64: 64, Valid: true}, target.Int64)
+		assert.Equal(t, sql.NullString{String: "val S", Valid: true}, target.String)
+		assert.Equal(t, sql.NullTime{Time: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true}, target.Time)
+		assert.Equal(t, []sql.NullInt64{{Int64: 1, Valid: true}, {Int64: 2, Valid: true}}, target.List)
+	})
+
+	dss.runSubtest(t, "null", func(t *testing.T, decode tDecode) {
+		target := struct {
+			Int64  sql.NullInt64
+			String sql.NullString
+		}{
+			Int64:  sql.NullInt64{Int64: 64, Valid: true},
+			String: sql.NullString{String: "orig", Valid: true},
+		}
+
+		decode("int64=&string", &target)
+		assert.Equal(t, sql.NullInt64{}, target.Int64)
+		assert.Equal(t, sql.NullString{}, target.String)
+	})
+}
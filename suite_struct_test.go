@@ -54,6 +54,54 @@ func (des decodeErrorSuite) runStructParseTagSubtests(t *testing.T) {
 			actual := decode("xyz", &target)
 			assertErrorMessage(t, "mutually exclusive base tag directive 'embed' and non-empty name", actual)
 		})
+
+		des.runSubtest(t, "empty directive value", func(t *testing.T, decode tDecode) {
+			var target struct {
+				Key string `qry:"keyA,layout="`
+			}
+			actual := decode("xyz", &target)
+			assertErrorMessage(t, "empty base tag directive 'layout' value", actual)
+		})
+
+		des.runSubtest(t, "invalid directive value escape", func(t *testing.T, decode tDecode) {
+			var target struct {
+				Key string `qry:"keyA,layout=%zz"`
+			}
+			actual := decode("xyz", &target)
+			assertErrorMessage(t, `invalid URL escape "%zz"`, actual)
+		})
+
+		des.runSubtest(t, "embed and flag", func(t *testing.T, decode tDecode) {
+			var target struct {
+				Embedded struct{ Key string } `qry:",embed,flag"`
+			}
+			actual := decode("xyz", &target)
+			assertErrorMessage(t, "mutually exclusive base tag directives 'embed' and 'flag'", actual)
+		})
+
+		des.runSubtest(t, "invalid integer base", func(t *testing.T, decode tDecode) {
+			var target struct {
+				Key int `qry:"keyA,base=37"`
+			}
+			actual := decode("xyz", &target)
+			assertErrorMessage(t, "invalid integer base 37", actual)
+		})
+
+		des.runSubtest(t, "invalid unescape", func(t *testing.T, decode tDecode) {
+			var target struct {
+				Key string `qry:"keyA,unescape=fragment"`
+			}
+			actual := decode("xyz", &target)
+			assertErrorMessage(t, "invalid base tag directive 'unescape' value 'fragment'", actual)
+		})
+
+		des.runSubtest(t, "embed and directive", func(t *testing.T, decode tDecode) {
+			var target struct {
+				Embedded struct{ Key string } `qry:",embed,layout=unix"`
+			}
+			actual := decode("xyz", &target)
+			assertErrorMessage(t, "mutually exclusive base tag directives 'embed' and 'layout'", actual)
+		})
 	})
 
 	t.Run("set", func(t *testing.T) {
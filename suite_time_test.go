@@ -0,0 +1,100 @@
+package qry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tTimeEST = time.FixedZone("EST", -5*60*60)
+
+func (dr decodeRunner) withTimeConfig() decodeRunner {
+	return dr.with(
+		qry.ConvertTimeLayoutsAs("2006-01-02", qry.TimeLayoutUnix),
+		qry.ConvertTimeLocationAs(tTimeEST),
+	)
+}
+
+// ===== Config error
+func (ces configErrorSuite) runTimeTests(t *testing.T) {
+	ces.runSubtest(t, "empty layout list", "empty time layout list", qry.ConvertTimeLayoutsAs())
+	ces.runSubtest(t, "nil location", "nil time location", qry.ConvertTimeLocationAs(nil))
+}
+
+// ===== Error
+func (des decodeErrorSuite) runTimeValueListTests(t *testing.T) {
+	des.withTimeConfig().runSubtest(t, "config layouts error", func(t *testing.T, decode tDecode) {
+		var target []time.Time
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "time matches none of the layouts [2006-01-02, unix]", actual)
+	})
+}
+
+func (des decodeErrorSuite) runTimeValueTests(t *testing.T) {
+	des.runSubtest(t, "duration error", func(t *testing.T, decode tDecode) {
+		var target time.Duration
+		actual := decode("90", &target)
+		assertErrorMessage(t, `time: missing unit in duration "90"`, actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runTimeQueryTests(t *testing.T) {
+	dss.runSubtest(t, "duration", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA time.Duration
+			KeyB []time.Duration
+		}
+
+		decode("keyA=1m30s&keyB=10ms,2h", &target)
+		assert.Equal(t, 90*time.Second, target.KeyA)
+		assert.Equal(t, []time.Duration{10 * time.Millisecond, 2 * time.Hour}, target.KeyB)
+	})
+
+	type tagLayouts struct {
+		Date   time.Time  `qry:"date,layout=2006-01-02"`
+		Stamp  *time.Time `qry:"stamp,layout=unixmilli"`
+		Header time.Time  `qry:"header,layout=Mon%2C 02 Jan 2006 15:04:05 MST,layout=unix"`
+		Other  time.Time  `qry:"other"`
+	}
+
+	dss.runSubtest(t, "tag layouts", func(t *testing.T, decode tDecode) {
+		var target tagLayouts
+
+		decode("date=2020-01-02&stamp=1577934245123&header=1577934245&other=2020-01-02T03:04:05Z", &target)
+		assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), target.Date)
+		assert.True(t, time.UnixMilli(1577934245123).Equal(*target.Stamp))
+		assert.True(t, time.Unix(1577934245, 0).Equal(target.Header))
+		assert.True(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC).Equal(target.Other))
+	})
+
+	dss.runSubtest(t, "tag layouts escaped", func(t *testing.T, decode tDecode) {
+		var target tagLayouts
+
+		decode("header=Thu%2C%2002%20Jan%202020%2003%3A04%3A05%20UTC", &target)
+		assert.True(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC).Equal(target.Header))
+	})
+}
+
+func (dss decodeSuccessSuite) runTimeValueListTests(t *testing.T) {
+	dss.withTimeConfig().runSubtest(t, "config layouts", func(t *testing.T, decode tDecode) {
+		var target []time.Time
+
+		decode("2020-01-02,1577934245", &target)
+		require.Len(t, target, 2)
+		assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, tTimeEST), target[0])
+		assert.True(t, time.Unix(1577934245, 0).Equal(target[1]))
+		assert.Equal(t, tTimeEST, target[1].Location())
+	})
+}
+
+func (dss decodeSuccessSuite) runTimeValueTests(t *testing.T) {
+	dss.runSubtest(t, "default layout", func(t *testing.T, decode tDecode) {
+		var target time.Time
+		decode("2020-01-02T03:04:05Z", &target)
+		assert.True(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC).Equal(target))
+	})
+}
@@ -0,0 +1,132 @@
+package qry_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tTraceEvent struct {
+	level qry.DecodeLevel
+	chain []string
+	input string
+}
+
+type tTraceRecorder struct{ events *[]tTraceEvent }
+
+func newTraceRecorder() tTraceRecorder { return tTraceRecorder{events: new([]tTraceEvent)} }
+
+func (ttr tTraceRecorder) Mark(level qry.DecodeLevel, input string, _ reflect.Value) {
+	*ttr.events = append(*ttr.events, tTraceEvent{level: level, input: input})
+}
+
+func (ttr tTraceRecorder) MarkKeyChain(chain []string, input string, _ reflect.Value) {
+	*ttr.events = append(*ttr.events, tTraceEvent{level: qry.LevelKeyChain, chain: chain, input: input})
+}
+
+func (ttr tTraceRecorder) Child() qry.Trace { return ttr }
+
+// tPlainTrace implements only the base Trace interface
+type tPlainTrace struct{ inputs *[]string }
+
+func (tpt tPlainTrace) Mark(level qry.DecodeLevel, input string, _ reflect.Value) {
+	if level == qry.LevelKeyChain {
+		*tpt.inputs = append(*tpt.inputs, input)
+	}
+}
+
+func (tpt tPlainTrace) Child() qry.Trace { return tpt }
+
+// ===== Error
+func (des decodeErrorSuite) runTraceQueryTests(t *testing.T) {
+	des.withDecodeErrors().with(qry.SeparateKeyChainBy('.'), qry.IgnoreInvalidKeys(false)).runSubtest(t, "key chain error", func(t *testing.T, decode tDecode) {
+		var (
+			target    map[string]string
+			decodeErr qry.DecodeError
+		)
+
+		actual := decode("keyA.keyX=val%20AX", &target)
+		require.True(t, assertErrorAs(t, &decodeErr, actual))
+		assert.Equal(t, qry.LevelKeyChain, decodeErr.Level)
+		assert.Equal(t, []string{"keyX"}, decodeErr.KeyChain)
+		assert.Equal(t, "val%20AX", decodeErr.Input)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runTraceQueryTests(t *testing.T) {
+	var (
+		input    = "keyA.keyX=val%20AX"
+		expected = map[string]map[string]string{"keyA": {"keyX": "val AX"}}
+		runner   = dss.withKeyChainSep('.')
+	)
+
+	// Measured outside the runner, which always supplies a trace of its own
+	t.Run("untraced allocations", func(t *testing.T) {
+		decoder, err := qry.NewDecoder(runner.opts...)
+		require.NoError(t, err, "decoder creation")
+
+		noop := qry.TraceMarker(func(qry.DecodeLevel, string, reflect.Value) {})
+
+		untraced := testing.AllocsPerRun(100, func() {
+			var target map[string]map[string]string
+			_ = decoder.DecodeQuery(input, &target)
+		})
+
+		traced := testing.AllocsPerRun(100, func() {
+			var target map[string]map[string]string
+			_ = decoder.DecodeQuery(input, &target, noop)
+		})
+
+		// The no-op trace itself allocates nothing, so any difference is trace
+		// work (key chain flattening, trace merging, etc.) done only if traced
+		assert.Less(t, untraced, traced)
+
+		var target map[string]map[string]string
+		require.NoError(t, decoder.DecodeQuery(input, &target))
+		assert.Equal(t, expected, target)
+	})
+
+	recorder := newTraceRecorder()
+	runner.withTraces(recorder).runSubtest(t, "key chain event", func(t *testing.T, decode tDecode) {
+		var target map[string]map[string]string
+
+		decode(input, &target)
+		assert.Equal(t, expected, target)
+
+		var chains [][]string
+		for _, event := range *recorder.events {
+			if event.level == qry.LevelKeyChain {
+				assert.Equal(t, "val%20AX", event.input)
+				chains = append(chains, event.chain)
+			}
+		}
+
+		assert.Equal(t, [][]string{{"keyA", "keyX"}, {"keyX"}}, chains)
+	})
+
+	var inputs []string
+	marker := qry.TraceMarker(func(level qry.DecodeLevel, input string, _ reflect.Value) {
+		if level == qry.LevelKeyChain {
+			inputs = append(inputs, input)
+		}
+	})
+
+	runner.withTraces(marker).runSubtest(t, "key chain marker", func(t *testing.T, decode tDecode) {
+		var target map[string]map[string]string
+
+		decode(input, &target)
+		assert.Equal(t, []string{"keyA, keyX | val%20AX", "keyX | val%20AX"}, inputs)
+	})
+
+	plain := tPlainTrace{inputs: new([]string)}
+	runner.withTraces(plain).runSubtest(t, "key chain plain trace", func(t *testing.T, decode tDecode) {
+		var target map[string]map[string]string
+
+		decode(input, &target)
+		assert.Equal(t, []string{"keyA, keyX | val%20AX", "keyX | val%20AX"}, *plain.inputs)
+	})
+}
@@ -0,0 +1,95 @@
+package qry_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+func transformReverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// ===== Config error
+func (ces configErrorSuite) runTransformTests(t *testing.T) {
+	ces.runSubtest(t, "empty name", "empty transform name", qry.ConvertTransformVia("", strings.TrimSpace))
+	ces.runSubtest(t, "nil function", "nil transform function 'xyz'", qry.ConvertTransformVia("xyz", nil))
+}
+
+// ===== Error
+func (des decodeErrorSuite) runTransformQueryTests(t *testing.T) {
+	des.runSubtest(t, "unknown transform error", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Key string `qry:"key,transform=missing"`
+		}
+
+		actual := decode("key=abc", &target)
+		assertErrorMessage(t, "unknown transform 'missing'", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runTransformQueryTests(t *testing.T) {
+	dss.runSubtest(t, "built-in", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Trim     string       `qry:"trim,trim"`
+			Lower    []string     `qry:"lower,lower"`
+			Upper    string       `qry:"upper,upper"`
+			Collapse string       `qry:"collapse,collapse"`
+			Number   int          `qry:"number,trim"`
+			Text     tUnmarshaler `qry:"text,trim,upper"`
+		}
+
+		decode("trim=%20val%20&lower=A,B&upper=val&collapse=%20a%20%20b%09c%20&number=%2042%20&text=%20val%20", &target)
+		assert.Equal(t, "val", target.Trim)
+		assert.Equal(t, []string{"a", "b"}, target.Lower)
+		assert.Equal(t, "VAL", target.Upper)
+		assert.Equal(t, "a b c", target.Collapse)
+		assert.Equal(t, 42, target.Number)
+		target.Text.assertCalledWithValue(t, "VAL")
+	})
+
+	dss.with(qry.ConvertTransformVia("reverse", transformReverse)).runSubtest(t, "registered", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Key string `qry:"key,trim,transform=reverse"`
+		}
+
+		decode("key=%20abc%20", &target)
+		assert.Equal(t, "cba", target.Key)
+	})
+
+	var outputs []string
+
+	marker := qry.TraceMarker(func(level qry.DecodeLevel, input string, _ reflect.Value) {
+		if level == qry.LevelTransform {
+			outputs = append(outputs, input)
+		}
+	})
+
+	dss.withTraces(marker).runSubtest(t, "trace", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Key string `qry:"key,trim,upper"`
+		}
+
+		decode("key=%20abc%20", &target)
+		assert.Equal(t, []string{"abc", "ABC"}, outputs)
+	})
+
+	trimUnderscores := func(s string) string { return strings.Trim(s, "_") }
+
+	dss.with(qry.ConvertTransformVia(qry.TransformTrim, trimUnderscores)).runSubtest(t, "override", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Key string `qry:"key,trim"`
+		}
+
+		decode("key=__abc__", &target)
+		assert.Equal(t, "abc", target.Key)
+	})
+}
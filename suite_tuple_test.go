@@ -0,0 +1,164 @@
+package qry_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	tTuplePoint struct{ X, Y int }
+
+	tTupleRange struct {
+		Max   int    `qry:"max,pos=1"`
+		Min   int    `qry:"min,pos=0"`
+		Label string `qry:"-"`
+		Step  *int   `qry:"step,pos=2"`
+	}
+
+	tTupleSize struct {
+		Width  int
+		Height int `qry:"height,optional"`
+		Unit   *string
+	}
+
+	tTupleInner struct{ B, C int }
+
+	tTupleEmbedded struct {
+		A int
+		tTupleInner
+		D int
+	}
+)
+
+func (dr decodeRunner) withTupleSeparators() decodeRunner {
+	return dr.with(qry.SeparateNestedValuesBy(';'))
+}
+
+// ===== Error
+func (des decodeErrorSuite) runTupleValueListTests(t *testing.T) {
+	runner := des.withTupleSeparators()
+
+	runner.runSubtest(t, "too many values error", func(t *testing.T, decode tDecode) {
+		var target tTuplePoint
+		actual := decode("1,2,3", &target)
+		assertErrorMessage(t, "too many values for struct", actual)
+	})
+
+	runner.runSubtest(t, "missing value error", func(t *testing.T, decode tDecode) {
+		var target tTuplePoint
+		actual := decode("1", &target)
+		assertErrorMessage(t, "missing value for struct field 'y'", actual)
+	})
+
+	runner.runSubtest(t, "value error", func(t *testing.T, decode tDecode) {
+		var target tTuplePoint
+		actual := decode("1,x", &target)
+		assertErrorMessage(t, "invalid syntax", actual)
+	})
+
+	runner.runSubtest(t, "duplicate position error", func(t *testing.T, decode tDecode) {
+		var target struct {
+			A int `qry:"a,pos=0"`
+			B int `qry:"b,pos=0"`
+		}
+		actual := decode("1", &target)
+		assertErrorMessage(t, "duplicate struct field position 0", actual)
+	})
+
+	runner.runSubtest(t, "missing position error", func(t *testing.T, decode tDecode) {
+		var target struct {
+			A int `qry:"a,pos=0"`
+			B int `qry:"b,pos=2"`
+		}
+		actual := decode("1", &target)
+		assertErrorMessage(t, "missing struct field position 1", actual)
+	})
+
+	runner.runSubtest(t, "invalid position error", func(t *testing.T, decode tDecode) {
+		var target struct {
+			A int `qry:"a,pos=-1"`
+		}
+		actual := decode("1", &target)
+		assertErrorMessage(t, "invalid base tag directive 'pos' value '-1'", actual)
+	})
+
+	runner.runSubtest(t, "mixed positions error", func(t *testing.T, decode tDecode) {
+		var target struct {
+			A int    `qry:"a,pos=0"`
+			B int    `qry:"b,pos=1"`
+			C string `qry:"c"`
+		}
+		actual := decode("1,2", &target)
+		assertErrorMessage(t, "unpositioned struct field 'c' among positioned fields", actual)
+	})
+
+	runner.runSubtest(t, "mixed embedded positions error", func(t *testing.T, decode tDecode) {
+		var target struct {
+			A int `qry:"a,pos=0"`
+			tTupleInner
+		}
+		actual := decode("1,2,3", &target)
+		assertErrorMessage(t, "unpositioned struct field 'b' among positioned fields", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runTupleQueryTests(t *testing.T) {
+	dss.withTupleSeparators().runSubtest(t, "field order", func(t *testing.T, decode tDecode) {
+		var target struct {
+			Point   tTuplePoint
+			PtrPt   *tTuplePoint
+			Polygon []tTuplePoint
+		}
+
+		decode("point=3,4&ptrPt=5,6&polygon=0,0;1,0;1,1", &target)
+		assert.Equal(t, tTuplePoint{3, 4}, target.Point)
+		assert.Equal(t, &tTuplePoint{5, 6}, target.PtrPt)
+		assert.Equal(t, []tTuplePoint{{0, 0}, {1, 0}, {1, 1}}, target.Polygon)
+	})
+}
+
+func (dss decodeSuccessSuite) runTupleValueListTests(t *testing.T) {
+	runner := dss.withTupleSeparators()
+
+	runner.runSubtest(t, "explicit positions", func(t *testing.T, decode tDecode) {
+		var target tTupleRange
+
+		decode("1,10", &target)
+		assert.Equal(t, tTupleRange{Min: 1, Max: 10}, target)
+	})
+
+	runner.runSubtest(t, "explicit positions optional", func(t *testing.T, decode tDecode) {
+		var target tTupleRange
+
+		decode("1,10,2", &target)
+		require.NotNil(t, target.Step)
+		assert.Equal(t, 2, *target.Step)
+	})
+
+	runner.runSubtest(t, "optional", func(t *testing.T, decode tDecode) {
+		var target tTupleSize
+
+		decode("3", &target)
+		assert.Equal(t, tTupleSize{Width: 3}, target)
+	})
+
+	runner.runSubtest(t, "optional present", func(t *testing.T, decode tDecode) {
+		var target tTupleSize
+
+		decode("3,4,cm", &target)
+		assert.Equal(t, 4, target.Height)
+		require.NotNil(t, target.Unit)
+		assert.Equal(t, "cm", *target.Unit)
+	})
+
+	runner.runSubtest(t, "embedded declaration order", func(t *testing.T, decode tDecode) {
+		var target tTupleEmbedded
+
+		decode("1,2,3,4", &target)
+		assert.Equal(t, tTupleEmbedded{A: 1, tTupleInner: tTupleInner{B: 2, C: 3}, D: 4}, target)
+	})
+}
@@ -0,0 +1,122 @@
+package qry_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oligarch316/qry"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	tFlagValue   string
+	tJSONValue   struct{ data string }
+	tBinaryValue string
+
+	tOptInAll string
+)
+
+func (tfv tFlagValue) String() string { return string(tfv) }
+func (tfv *tFlagValue) Set(s string) error {
+	*tfv = tFlagValue("flag " + s)
+	return nil
+}
+
+func (tjv *tJSONValue) UnmarshalJSON(data []byte) error {
+	if !json.Valid(data) {
+		return assert.AnError
+	}
+	tjv.data = string(data)
+	return nil
+}
+
+func (tbv *tBinaryValue) UnmarshalBinary(data []byte) error {
+	*tbv = tBinaryValue(data)
+	return nil
+}
+
+func (toia tOptInAll) String() string { return string(toia) }
+func (toia *tOptInAll) Set(s string) error {
+	*toia = tOptInAll("flag " + s)
+	return nil
+}
+func (toia *tOptInAll) UnmarshalJSON(data []byte) error {
+	*toia = tOptInAll("json " + string(data))
+	return nil
+}
+
+// ===== Error
+func (des decodeErrorSuite) runUnmarshalOptInQueryTests(t *testing.T) {
+	runner := des.with(
+		qry.ConvertByteEncodingAs(qry.ByteEncodingHex),
+		qry.UnmarshalBinaryValues(true),
+	)
+
+	runner.runSubtest(t, "binary error", func(t *testing.T, decode tDecode) {
+		var target struct{ KeyA tBinaryValue }
+		actual := decode("keyA=xyz", &target)
+		assertErrorMessage(t, "encoding/hex: invalid byte: U+0078 'x'", actual)
+	})
+}
+
+func (des decodeErrorSuite) runUnmarshalOptInValueTests(t *testing.T) {
+	des.runSubtest(t, "disabled error", func(t *testing.T, decode tDecode) {
+		var target tJSONValue
+		actual := decode("xyz", &target)
+		assertErrorMessage(t, "unsupported target type", actual)
+	})
+}
+
+// ===== Success
+func (dss decodeSuccessSuite) runUnmarshalOptInQueryTests(t *testing.T) {
+	dss.with(qry.UnmarshalJSONValues(true)).runSubtest(t, "json", func(t *testing.T, decode tDecode) {
+		var target struct{ KeyA, KeyB, KeyC tJSONValue }
+
+		decode(`keyA=xyz&keyB=%7B%22x%22%3A1%7D&keyC=42`, &target)
+		assert.Equal(t, `"xyz"`, target.KeyA.data)
+		assert.Equal(t, `{"x":1}`, target.KeyB.data)
+		assert.Equal(t, `42`, target.KeyC.data)
+	})
+
+	dss.with(
+		qry.ConvertByteEncodingAs(qry.ByteEncodingHex),
+		qry.UnmarshalBinaryValues(true),
+	).runSubtest(t, "binary", func(t *testing.T, decode tDecode) {
+		var target struct {
+			KeyA tBinaryValue
+			KeyB tBinaryValue `qry:"keyB,encoding=base64url"`
+		}
+
+		decode("keyA=78797a&keyB=eHl6", &target)
+		assert.Equal(t, tBinaryValue("xyz"), target.KeyA)
+		assert.Equal(t, tBinaryValue("xyz"), target.KeyB)
+	})
+}
+
+func (dss decodeSuccessSuite) runUnmarshalOptInValueListTests(t *testing.T) {
+	dss.with(qry.UnmarshalFlagValues(true)).runSubtest(t, "flag", func(t *testing.T, decode tDecode) {
+		var target []tFlagValue
+		decode("a%20b,c", &target)
+		assert.Equal(t, []tFlagValue{"flag a b", "flag c"}, target)
+	})
+}
+
+func (dss decodeSuccessSuite) runUnmarshalOptInValueTests(t *testing.T) {
+	dss.runSubtest(t, "disabled", func(t *testing.T, decode tDecode) {
+		var target tFlagValue
+		decode("xyz", &target)
+		assert.Equal(t, tFlagValue("xyz"), target, "check converted as plain string")
+	})
+
+	dss.with(qry.UnmarshalFlagValues(true), qry.UnmarshalJSONValues(true)).runSubtest(t, "flag priority", func(t *testing.T, decode tDecode) {
+		var target tOptInAll
+		decode("xyz", &target)
+		assert.Equal(t, tOptInAll("flag xyz"), target)
+	})
+
+	dss.with(qry.UnmarshalJSONValues(true)).runSubtest(t, "json priority", func(t *testing.T, decode tDecode) {
+		var target tOptInAll
+		decode("xyz", &target)
+		assert.Equal(t, tOptInAll(`json "xyz"`), target)
+	})
+}
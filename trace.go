@@ -1,7 +1,10 @@
 package qry
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/disiqueira/gotree"
 )
@@ -12,16 +15,54 @@ type Trace interface {
 	Child() Trace
 }
 
-func mergeTraces(traces []Trace) Trace {
-	switch len(traces) {
+// KeyChainTrace TODO
+// Traces not implementing KeyChainTrace receive key chain events flattened into
+// the input string of a LevelKeyChain mark
+type KeyChainTrace interface {
+	Trace
+	MarkKeyChain([]string, string, reflect.Value)
+}
+
+func markKeyChain(t Trace, chain []string, input string, target reflect.Value) {
+	if kct, ok := t.(KeyChainTrace); ok {
+		kct.MarkKeyChain(chain, input, target)
+		return
+	}
+
+	// Flatten the chain into the input string, as plain traces have no
+	// structured notion of a key chain
+	t.Mark(LevelKeyChain, fmt.Sprintf("%s | %s", strings.Join(chain, ", "), input), target)
+}
+
+// ContextTrace TODO
+type ContextTrace interface {
+	Trace
+	WithContext(context.Context) Trace
+}
+
+func mergeTraces(ctx context.Context, traces []Trace) Trace {
+	var res TraceList
+
+	for _, t := range traces {
+		switch tt := t.(type) {
+		case nil:
+			continue
+		case ContextTrace:
+			res = append(res, tt.WithContext(ctx))
+		default:
+			res = append(res, t)
+		}
+	}
+
+	switch len(res) {
 	case 0:
-		// No-op
-		return TraceMarker(func(DecodeLevel, string, reflect.Value) {})
+		// No trace => nil, checked for by DecodeState prior to any trace work
+		return nil
 	case 1:
-		return traces[0]
+		return res[0]
 	}
 
-	return TraceList(traces)
+	return res
 }
 
 // TraceList TODO
@@ -34,6 +75,13 @@ func (tl TraceList) Mark(level DecodeLevel, input string, target reflect.Value)
 	}
 }
 
+// MarkKeyChain TODO
+func (tl TraceList) MarkKeyChain(chain []string, input string, target reflect.Value) {
+	for _, t := range tl {
+		markKeyChain(t, chain, input, target)
+	}
+}
+
 // Child TODO
 func (tl TraceList) Child() Trace {
 	res := make(TraceList, len(tl))
@@ -54,6 +102,24 @@ func (tm TraceMarker) Mark(level DecodeLevel, input string, target reflect.Value
 // Child TODO
 func (tm TraceMarker) Child() Trace { return tm }
 
+// ContextTraceMarker TODO
+type ContextTraceMarker func(context.Context, DecodeLevel, string, reflect.Value)
+
+// Mark TODO
+func (ctm ContextTraceMarker) Mark(level DecodeLevel, input string, target reflect.Value) {
+	ctm(context.Background(), level, input, target)
+}
+
+// Child TODO
+func (ctm ContextTraceMarker) Child() Trace { return ctm }
+
+// WithContext TODO
+func (ctm ContextTraceMarker) WithContext(ctx context.Context) Trace {
+	return TraceMarker(func(level DecodeLevel, input string, target reflect.Value) {
+		ctm(ctx, level, input, target)
+	})
+}
+
 // TraceTree TODO
 type TraceTree struct{ TraceTreeNode }
 
@@ -77,6 +143,11 @@ func (ttn *TraceTreeNode) Mark(level DecodeLevel, input string, target reflect.V
 	ttn.DecodeInfo = level.newInfo(input, target)
 }
 
+// MarkKeyChain TODO
+func (ttn *TraceTreeNode) MarkKeyChain(chain []string, input string, target reflect.Value) {
+	ttn.DecodeInfo = newKeyChainInfo(chain, input, target)
+}
+
 // Child TODO
 func (ttn *TraceTreeNode) Child() Trace {
 	res := new(TraceTreeNode)
@@ -1,13 +1,31 @@
 package qry
 
 import (
+	"context"
 	"encoding"
+	"encoding/json"
+	"errors"
+	"flag"
 	"reflect"
 )
 
 // RawTextUnmarshaler TODO
 type RawTextUnmarshaler interface{ UnmarshalRawText([]byte) error }
 
+// ContextTextUnmarshaler TODO
+type ContextTextUnmarshaler interface {
+	UnmarshalTextContext(context.Context, []byte) error
+}
+
+// QryUnmarshaler TODO
+// Checked prior to all other unmarshaler interfaces and regardless of
+// AllowLiteral, allowing custom containers to split and decode their parts via
+// the given state, thereby retaining its context, limits, trace, etc.
+// A returned DecodeError (e.g. from the state's Decode) is passed through as-is
+type QryUnmarshaler interface {
+	UnmarshalQry(level DecodeLevel, raw string, state *DecodeState) error
+}
+
 // RawString TODO
 type RawString string
 
@@ -17,41 +35,112 @@ func (rs *RawString) UnmarshalRawText(text []byte) error {
 	return nil
 }
 
+// ConfigUnmarshal TODO
+//
+// Unmarshaler interfaces are checked in the following priority order, with the
+// opt-in interfaces considered only when enabled:
+// 1. RawTextUnmarshaler
+// 2. ContextTextUnmarshaler
+// 3. encoding.TextUnmarshaler
+// 4. flag.Value (opt-in)
+// 5. json.Unmarshaler (opt-in)
+// 6. encoding.BinaryUnmarshaler (opt-in)
+type ConfigUnmarshal struct {
+	// Binary TODO
+	// Unescaped input is decoded per the applicable byte encoding
+	Binary bool
+
+	// Flag TODO
+	Flag bool
+
+	// JSON TODO
+	// Unescaped input is passed as-is when valid JSON, otherwise as a JSON string
+	JSON bool
+}
+
 type unmarshaler struct {
-	textUnmarshalerT, rawTextUnmarshalerT reflect.Type
-	unescape                              func(string) (string, error)
+	ConfigUnmarshal
+	qryUnmarshalerT                                                reflect.Type
+	textUnmarshalerT, rawTextUnmarshalerT, contextTextUnmarshalerT reflect.Type
+	binaryUnmarshalerT, flagValueT, jsonUnmarshalerT               reflect.Type
 }
 
-func newUnmarshaler(unescape func(string) (string, error)) *unmarshaler {
+func newUnmarshaler(cfg ConfigUnmarshal) *unmarshaler {
 	var (
+		qu  QryUnmarshaler
 		tu  encoding.TextUnmarshaler
 		rtu RawTextUnmarshaler
+		ctu ContextTextUnmarshaler
+		bu  encoding.BinaryUnmarshaler
+		fv  flag.Value
+		ju  json.Unmarshaler
 	)
 
 	return &unmarshaler{
-		textUnmarshalerT:    reflect.TypeOf(&tu).Elem(),
-		rawTextUnmarshalerT: reflect.TypeOf(&rtu).Elem(),
-		unescape:            unescape,
+		ConfigUnmarshal:         cfg,
+		qryUnmarshalerT:         reflect.TypeOf(&qu).Elem(),
+		textUnmarshalerT:        reflect.TypeOf(&tu).Elem(),
+		rawTextUnmarshalerT:     reflect.TypeOf(&rtu).Elem(),
+		contextTextUnmarshalerT: reflect.TypeOf(&ctu).Elem(),
+		binaryUnmarshalerT:      reflect.TypeOf(&bu).Elem(),
+		flagValueT:              reflect.TypeOf(&fv).Elem(),
+		jsonUnmarshalerT:        reflect.TypeOf(&ju).Elem(),
 	}
 }
 
 func (u *unmarshaler) check(t reflect.Type) bool {
-	return t.Implements(u.textUnmarshalerT) || t.Implements(u.rawTextUnmarshalerT)
+	return t.Implements(u.qryUnmarshalerT) ||
+		t.Implements(u.textUnmarshalerT) ||
+		t.Implements(u.rawTextUnmarshalerT) ||
+		t.Implements(u.contextTextUnmarshalerT) ||
+		(u.Flag && t.Implements(u.flagValueT)) ||
+		(u.JSON && t.Implements(u.jsonUnmarshalerT)) ||
+		(u.Binary && t.Implements(u.binaryUnmarshalerT))
 }
 
-func (u *unmarshaler) handle(level DecodeLevel, raw string, val reflect.Value) (bool, error) {
-	var err error
+func (u *unmarshaler) handleQry(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+	t, ok := val.Interface().(QryUnmarshaler)
+	if !ok {
+		return false, nil
+	}
+
+	err := t.UnmarshalQry(level, raw, state)
+	if err == nil {
+		return true, nil
+	}
+
+	var decodeErr DecodeError
+	if errors.As(err, &decodeErr) {
+		return true, err
+	}
+
+	return true, level.wrapError(err, raw, val)
+}
+
+func (u *unmarshaler) handle(level DecodeLevel, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+	var (
+		iface = val.Interface()
+		err   error
+	)
 
-	switch t := val.Interface().(type) {
+	switch t := iface.(type) {
 	case RawTextUnmarshaler:
 		err = t.UnmarshalRawText([]byte(raw))
+	case ContextTextUnmarshaler:
+		var unescaped string
+		if unescaped, err = state.Unescape(raw, val); err == nil {
+			err = t.UnmarshalTextContext(state.ctx, []byte(unescaped))
+		}
 	case encoding.TextUnmarshaler:
 		var unescaped string
-		if unescaped, err = u.unescape(raw); err == nil {
+		if unescaped, err = state.Unescape(raw, val); err == nil {
 			err = t.UnmarshalText([]byte(unescaped))
 		}
 	default:
-		return false, nil
+		var complete bool
+		if complete, err = u.handleOptIn(iface, raw, val, state); !complete {
+			return false, nil
+		}
 	}
 
 	if err != nil {
@@ -60,3 +149,43 @@ func (u *unmarshaler) handle(level DecodeLevel, raw string, val reflect.Value) (
 
 	return true, err
 }
+
+func (u *unmarshaler) handleOptIn(iface interface{}, raw string, val reflect.Value, state *DecodeState) (bool, error) {
+	if t, ok := iface.(flag.Value); ok && u.Flag {
+		unescaped, err := state.Unescape(raw, val)
+		if err != nil {
+			return true, err
+		}
+		return true, t.Set(unescaped)
+	}
+
+	if t, ok := iface.(json.Unmarshaler); ok && u.JSON {
+		unescaped, err := state.Unescape(raw, val)
+		if err != nil {
+			return true, err
+		}
+
+		data := []byte(unescaped)
+		if !json.Valid(data) {
+			if data, err = json.Marshal(unescaped); err != nil {
+				return true, err
+			}
+		}
+		return true, t.UnmarshalJSON(data)
+	}
+
+	if t, ok := iface.(encoding.BinaryUnmarshaler); ok && u.Binary {
+		unescaped, err := state.Unescape(raw, val)
+		if err != nil {
+			return true, err
+		}
+
+		data, err := state.convert.byteEncoding(unescaped)
+		if err != nil {
+			return true, err
+		}
+		return true, t.UnmarshalBinary(data)
+	}
+
+	return false, nil
+}